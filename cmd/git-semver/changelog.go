@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"time"
+
+	"github.com/softsense/git-semver/pkg/git"
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	rootCmd.AddCommand(changelogCmd)
+	changelogCmd.Flags().String("file", "", "prepend the release to a Keep a Changelog file instead of printing it")
+	if err := viper.BindPFlag("changelog.file", changelogCmd.Flags().Lookup("file")); err != nil {
+		log.Fatal(err)
+	}
+	changelogCmd.Flags().String("version", "", "version of the release, defaults to the next version")
+	if err := viper.BindPFlag("changelog.version", changelogCmd.Flags().Lookup("version")); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Print a changelog section for the changes since last tag.",
+	Run: func(cmd *cobra.Command, args []string) {
+		g, err := openRepo()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var v semver.Version
+		if s := viper.GetString("changelog.version"); s != "" {
+			v, err = g.Parse(s)
+		} else {
+			v, err = nextVersion(g)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if v.Prefix == "" {
+			v.Prefix = viper.GetString("prefix")
+		}
+
+		date, ok, err := g.TagDate(v)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !ok {
+			date = time.Now()
+		}
+
+		section, err := g.Changelog(v, date)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		file := viper.GetString("changelog.file")
+		if file != "" {
+			existing, err := os.ReadFile(file)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				log.Fatal(err)
+			}
+			out, err := git.PrependChangelog(string(existing), section)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if err := os.WriteFile(file, []byte(out), 0o644); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		switch {
+		case jsonOutput():
+			out := changelogOutput{Version: g.Format(v), Date: date.Format("2006-01-02"), Changelog: section, File: file}
+			if err := printJSON(out); err != nil {
+				log.Fatal(err)
+			}
+		case file == "":
+			fmt.Print(section)
+		}
+	},
+}
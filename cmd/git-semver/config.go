@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/softsense/git-semver/pkg/config"
+	"github.com/spf13/viper"
+)
+
+// loadConfig merges the configuration file into viper, below the command
+// line flags. Without --config the file is optional. --component replaces
+// the prefix and paths with those of a component in the file.
+func loadConfig() error {
+	component := viper.GetString("component")
+	path := viper.GetString("config")
+	if path == "" {
+		path = filepath.Join(viper.GetString("repo"), config.DefaultFile)
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			if component != "" {
+				return fmt.Errorf("unknown component %q, there is no %s", component, config.DefaultFile)
+			}
+			return nil
+		}
+	}
+
+	f, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	if component != "" {
+		if f, err = f.WithComponent(component); err != nil {
+			return err
+		}
+	}
+	return viper.MergeConfigMap(f.Settings())
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/softsense/git-semver/pkg/git"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	// exitNoVersion is the exit code of current if there is no version tag
+	exitNoVersion = 2
+
+	// exitNotAtHead is the exit code of current --exact if HEAD is not tagged
+	exitNotAtHead = 3
+)
+
+// currentOutput is the document printed by the current command with
+// --output json.
+type currentOutput struct {
+	Version string `json:"version"`
+	Prefix  string `json:"prefix"`
+	Head    string `json:"head"`
+	AtHead  bool   `json:"at_head"`
+}
+
+func init() {
+	rootCmd.AddCommand(currentCmd)
+	currentCmd.Flags().Bool("exact", false, fmt.Sprintf("exit with code %d unless HEAD is tagged with the current version", exitNotAtHead))
+	if err := viper.BindPFlag("current.exact", currentCmd.Flags().Lookup("exact")); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var currentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Print the current version without bumping it.",
+	Long: fmt.Sprintf(`Print the highest version without bumping it. Exits with code %d if there
+is no version tag.`, exitNoVersion),
+	Run: func(cmd *cobra.Command, args []string) {
+		g, err := openRepo()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		v, atHead, err := g.Current()
+		if errors.Is(err, git.ErrNoVersion) {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitNoVersion)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if viper.GetBool("current.exact") && !atHead {
+			fmt.Fprintf(os.Stderr, "HEAD is not tagged with %s\n", g.Format(v))
+			os.Exit(exitNotAtHead)
+		}
+
+		if jsonOutput() {
+			head, err := g.Head()
+			if err != nil {
+				log.Fatal(err)
+			}
+			err = printJSON(currentOutput{
+				Version: g.Format(v),
+				Prefix:  v.Prefix,
+				Head:    head.String(),
+				AtHead:  atHead,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		s, err := formatVersion(g, v)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(s)
+	},
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	rootCmd.AddCommand(verifyGoModCmd)
+	verifyGoModCmd.Flags().String("file", "", "go.mod to check, relative to the repository root (default go.mod in the directory of the prefix)")
+	if err := viper.BindPFlag("gomod.file", verifyGoModCmd.Flags().Lookup("file")); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var verifyGoModCmd = &cobra.Command{
+	Use:   "verify-gomod",
+	Short: "Check that the go.mod module path matches the next major version.",
+	Long: `Check that the module path in go.mod has the major version suffix of the
+next version, /v2 for v2.x.x and none for v0 and v1, as required by Go
+modules. Fails with the change to make if it does not.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		g, err := openRepo()
+		if err != nil {
+			log.Fatal(err)
+		}
+		n, err := nextVersion(g)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		module, err := g.VerifyGoMod(n, viper.GetString("gomod.file"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("module %s matches %s\n", module, g.Format(n))
+	},
+}
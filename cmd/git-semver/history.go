@@ -5,7 +5,6 @@ import (
 	"log"
 
 	"github.com/softsense/git-semver/pkg/git"
-	"github.com/softsense/git-semver/pkg/semver"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -16,30 +15,49 @@ func init() {
 	if err := viper.BindPFlag("msg-prefix", historyCmd.PersistentFlags().Lookup("msg-prefix")); err != nil {
 		log.Fatal(err)
 	}
+	historyCmd.Flags().String("from", "", "start after this tag, version, branch or commit (default the last tag)")
+	if err := viper.BindPFlag("history.from", historyCmd.Flags().Lookup("from")); err != nil {
+		log.Fatal(err)
+	}
+	historyCmd.Flags().String("to", "", "end at this tag, version, branch or commit (default HEAD or ref)")
+	if err := viper.BindPFlag("history.to", historyCmd.Flags().Lookup("to")); err != nil {
+		log.Fatal(err)
+	}
 }
 
 var historyCmd = &cobra.Command{
 	Use:   "history",
 	Short: "Print history since last tag.",
+	Long: `Print the commits since the last tag, or with --from and --to the commits
+reachable from --to but not from --from, including commits of merged
+branches.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		var below *semver.Version
-		if viper.GetString("below") != "" {
-			v, err := semver.Parse(viper.GetString("below"))
+		g, err := openRepo()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if jsonOutput() {
+			n, err := nextVersion(g)
 			if err != nil {
 				log.Fatal(err)
 			}
-			below = &v
-		}
-		g, err := git.Open(viper.GetString("repo"), git.Config{
-			Prefix:    viper.GetString("prefix"),
-			Below:     below,
-			IncludeRC: viper.GetBool("rc"),
-		})
-		if err != nil {
-			log.Fatal(err)
+			v, err := newVersionOutput(g, n)
+			if err != nil {
+				log.Fatal(err)
+			}
+			commits, err := g.CommitsBetween(viper.GetString("history.from"), viper.GetString("history.to"))
+			if err != nil {
+				log.Fatal(err)
+			}
+			out := historyOutput{versionOutput: v, Commits: commits, Issues: git.ReferencedIssues(commits)}
+			if err := printJSON(out); err != nil {
+				log.Fatal(err)
+			}
+			return
 		}
 
-		history, err := g.History(viper.GetString("msg-prefix"))
+		history, err := g.HistoryBetween(viper.GetString("msg-prefix"), viper.GetString("history.from"), viper.GetString("history.to"))
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/softsense/git-semver/pkg/git"
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().Bool("prerelease", false, "only list prereleases")
+	if err := viper.BindPFlag("list.prerelease", listCmd.Flags().Lookup("prerelease")); err != nil {
+		log.Fatal(err)
+	}
+	listCmd.Flags().Bool("stable", false, "only list final versions")
+	if err := viper.BindPFlag("list.stable", listCmd.Flags().Lookup("stable")); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List version tags.",
+	Long: `List every tag that parses as a version with the prefix, sorted by
+precedence, with the tagged commit, the tag date, whether the tag is
+annotated and whether it is reachable from HEAD or ref. --below and
+--within filter the list.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		g, err := openRepo()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		tags, err := g.Tags()
+		if err != nil {
+			log.Fatal(err)
+		}
+		tags, err = filterTags(g, tags)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if jsonOutput() {
+			if tags == nil {
+				tags = []git.Tag{}
+			}
+			if err := printJSON(tags); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, t := range tags {
+			kind, reachable := "lightweight", "unreachable"
+			if t.Annotated {
+				kind = "annotated"
+			}
+			if t.Reachable {
+				reachable = "reachable"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", t.Name, t.Hash[:7], t.Date.Format("2006-01-02"), kind, reachable)
+		}
+		if err := w.Flush(); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// filterTags applies the --prerelease, --stable, --below and --within flags.
+func filterTags(g *git.Git, tags []git.Tag) ([]git.Tag, error) {
+	var below *semver.Version
+	if s := viper.GetString("below"); s != "" {
+		v, err := g.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		below = &v
+	}
+	var within *semver.Range
+	if s := viper.GetString("within"); s != "" {
+		r, err := semver.ParseRange(s)
+		if err != nil {
+			return nil, err
+		}
+		within = &r
+	}
+
+	var out []git.Tag
+	for _, t := range tags {
+		switch {
+		case viper.GetBool("list.prerelease") && len(t.Version.Pre) == 0:
+		case viper.GetBool("list.stable") && len(t.Version.Pre) > 0:
+		case below != nil && t.Version.GTE(*below):
+		case within != nil && !within.Contains(t.Version):
+		default:
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
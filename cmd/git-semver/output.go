@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/softsense/git-semver/pkg/git"
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/spf13/viper"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+const (
+	formatTag    = "tag"
+	formatPEP440 = "pep440"
+	formatMaven  = "maven"
+)
+
+// versionOutput is the document printed by the root command with
+// --output json.
+type versionOutput struct {
+	Previous string `json:"previous"`
+	Next     string `json:"next"`
+	Bump     string `json:"bump"`
+	Prefix   string `json:"prefix"`
+	Head     string `json:"head"`
+}
+
+// historyOutput is the document printed by the history command with
+// --output json.
+type historyOutput struct {
+	versionOutput
+	Commits []git.Commit `json:"commits"`
+	Issues  []git.Issue  `json:"issues,omitempty"`
+}
+
+// tagOutput is the document printed by the tag command with --output json.
+type tagOutput struct {
+	versionOutput
+	Tag    string `json:"tag"`
+	Pushed bool   `json:"pushed"`
+}
+
+// changelogOutput is the document printed by the changelog command with
+// --output json.
+type changelogOutput struct {
+	Version   string `json:"version"`
+	Date      string `json:"date"`
+	Changelog string `json:"changelog"`
+	File      string `json:"file,omitempty"`
+}
+
+func validateOutput() error {
+	switch o := viper.GetString("output"); o {
+	case outputText, outputJSON:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q, use %q or %q", o, outputText, outputJSON)
+	}
+}
+
+func validateFormat() error {
+	switch f := viper.GetString("format"); f {
+	case formatTag, formatPEP440, formatMaven:
+		return nil
+	default:
+		return fmt.Errorf("unknown version format %q, use %q, %q or %q", f, formatTag, formatPEP440, formatMaven)
+	}
+}
+
+// formatVersion formats v for printing as selected by --format.
+func formatVersion(g *git.Git, v semver.Version) (string, error) {
+	switch viper.GetString("format") {
+	case formatPEP440:
+		return v.PEP440()
+	case formatMaven:
+		return v.Maven(), nil
+	default:
+		return g.Format(v), nil
+	}
+}
+
+func jsonOutput() bool {
+	return viper.GetString("output") == outputJSON
+}
+
+func newVersionOutput(g *git.Git, next semver.Version) (versionOutput, error) {
+	head, err := g.Head()
+	if err != nil {
+		return versionOutput{}, err
+	}
+	prev := g.Highest()
+	if viper.GetBool("release") {
+		prev = g.Prerelease()
+	}
+	return versionOutput{
+		Previous: g.Format(prev),
+		Next:     g.Format(next),
+		Bump:     bumpType(prev, next),
+		Prefix:   next.Prefix,
+		Head:     head.String(),
+	}, nil
+}
+
+// bumpType names the part of the version that differs between prev and
+// next.
+func bumpType(prev, next semver.Version) string {
+	switch {
+	case prev.Major != next.Major:
+		return git.BumpMajor.String()
+	case prev.Minor != next.Minor:
+		return git.BumpMinor.String()
+	case prev.Patch != next.Patch:
+		return git.BumpPatch.String()
+	case prev.Compare(next) != 0:
+		return "prerelease"
+	default:
+		return git.BumpNone.String()
+	}
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -5,7 +5,9 @@ import (
 	"log"
 	"os"
 
+	"github.com/softsense/git-semver/pkg/config"
 	"github.com/softsense/git-semver/pkg/git"
+	"github.com/softsense/git-semver/pkg/hosting"
 	"github.com/softsense/git-semver/pkg/semver"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -16,31 +18,162 @@ var rootCmd = &cobra.Command{
 	Use:   "git-semver",
 	Short: "A tool for bumping semantic versions based on git tags.",
 	Long:  `A tool for bumping semantic versions based on git tags.`,
+	// execute prints errors, which are about the repository or the
+	// configuration more often than about the usage
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := loadConfig(); err != nil {
+			return err
+		}
+		if err := validateOutput(); err != nil {
+			return err
+		}
+		return validateFormat()
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		var below *semver.Version
-		if viper.GetString("below") != "" {
-			v, err := semver.Parse(viper.GetString("below"))
+		g, err := openRepo()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		n, err := nextVersion(g)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if jsonOutput() {
+			out, err := newVersionOutput(g, n)
 			if err != nil {
 				log.Fatal(err)
 			}
-			below = &v
+			if err := printJSON(out); err != nil {
+				log.Fatal(err)
+			}
+			return
 		}
-		g, err := git.Open(viper.GetString("repo"), git.Config{
-			Prefix:    viper.GetString("prefix"),
-			Below:     below,
-			IncludeRC: viper.GetBool("rc"),
-		})
+
+		s, err := formatVersion(g, n)
 		if err != nil {
 			log.Fatal(err)
 		}
+		fmt.Println(s)
+	},
+}
 
-		n, err := g.Increment(viper.GetBool("major"), viper.GetBool("minor"), viper.GetBool("patch"), viper.GetBool("snapshot"), viper.GetBool("rc"))
+// openRepo opens the repository using the persistent flags.
+func openRepo() (*git.Git, error) {
+	scheme, err := git.ParseScheme(viper.GetString("scheme"))
+	if err != nil {
+		return nil, err
+	}
+	var below *semver.Version
+	if viper.GetString("below") != "" {
+		v, err := scheme.Parse(viper.GetString("below"))
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
+		}
+		below = &v
+	}
+	var within *semver.Range
+	if viper.GetString("within") != "" {
+		r, err := semver.ParseRange(viper.GetString("within"))
+		if err != nil {
+			return nil, err
+		}
+		within = &r
+	}
+	var channels []git.Channel
+	for _, s := range viper.GetStringSlice("channel") {
+		c, err := git.ParseChannel(s)
+		if err != nil {
+			return nil, err
 		}
+		channels = append(channels, c)
+	}
+	var hosts []hosting.Host
+	for _, s := range viper.GetStringSlice("host") {
+		h, err := hosting.ParseHost(s)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, h)
+	}
+	var issues []hosting.IssueTracker
+	for _, s := range viper.GetStringSlice("issue") {
+		t, err := hosting.ParseIssueTracker(s)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, t)
+	}
+	rules, err := config.BumpRules(viper.GetStringMapString("bump.rules"))
+	if err != nil {
+		return nil, err
+	}
+	return git.Open(viper.GetString("repo"), git.Config{
+		Prefix:      viper.GetString("prefix"),
+		Below:       below,
+		Within:      within,
+		IncludeRC:   viper.GetBool("rc"),
+		Ref:         viper.GetString("ref"),
+		AllTags:     viper.GetBool("all-tags"),
+		Paths:       viper.GetStringSlice("path"),
+		Channels:    channels,
+		Branch:      viper.GetString("branch"),
+		BumpRules:   rules,
+		Scheme:      scheme,
+		FirstParent: viper.GetBool("first-parent"),
+		NoMerges:    viper.GetBool("no-merges"),
+		Hosts:       hosts,
+		Issues:      issues,
+	})
+}
 
-		fmt.Println(n.String())
-	},
+// nextVersion computes the next version using the bump flags, checking the
+// worktree with --require-clean and marking it with --mark-dirty.
+func nextVersion(g *git.Git) (semver.Version, error) {
+	if viper.GetBool("require-clean") {
+		if err := g.RequireClean(); err != nil {
+			return semver.Version{}, err
+		}
+	}
+	n, err := bump(g)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	if viper.GetBool("mark-dirty") {
+		return g.MarkDirty(n)
+	}
+	return n, nil
+}
+
+// bump computes the next version using the bump flags.
+func bump(g *git.Git) (semver.Version, error) {
+	if viper.GetBool("describe") {
+		return g.Describe()
+	}
+	if viper.GetBool("pseudo") {
+		return g.PseudoVersion()
+	}
+	if viper.GetBool("release") {
+		return g.Promote(viper.GetBool("allow-descendant"))
+	}
+
+	major, minor, patch := viper.GetBool("major"), viper.GetBool("minor"), viper.GetBool("patch")
+	if viper.GetBool("auto") {
+		d, err := g.InferBump()
+		if err != nil {
+			return semver.Version{}, err
+		}
+		fmt.Fprintf(os.Stderr, "bump %s: %s\n", d.Bump, d.Reason)
+		// fall back to a patch release if no commit calls for a bump
+		major, minor, patch = d.Bump == git.BumpMajor, d.Bump == git.BumpMinor, d.Bump <= git.BumpPatch
+	}
+
+	// on a prerelease channel every bump is a prerelease
+	_, onChannel := g.Channel()
+	return g.Increment(major, minor, patch, viper.GetBool("snapshot"), viper.GetBool("rc") || onChannel)
 }
 
 func init() {
@@ -49,18 +182,38 @@ func init() {
 		log.Fatal(err)
 	}
 
-	rootCmd.Flags().Bool("major", false, "bump major version")
-	if err := viper.BindPFlag("major", rootCmd.Flags().Lookup("major")); err != nil {
+	rootCmd.PersistentFlags().String("config", "", "path to config file (default \".git-semver.yaml\" in the repository)")
+	if err := viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().StringP("output", "o", outputText, "output format, text or json")
+	if err := viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("format", formatTag, "print versions as the tag, in Python PEP 440 (pep440) or Maven (maven) format")
+	if err := viper.BindPFlag("format", rootCmd.PersistentFlags().Lookup("format")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("major", false, "bump major version")
+	if err := viper.BindPFlag("major", rootCmd.PersistentFlags().Lookup("major")); err != nil {
 		log.Fatal(err)
 	}
 
-	rootCmd.Flags().Bool("minor", false, "bump minor version")
-	if err := viper.BindPFlag("minor", rootCmd.Flags().Lookup("minor")); err != nil {
+	rootCmd.PersistentFlags().Bool("minor", false, "bump minor version")
+	if err := viper.BindPFlag("minor", rootCmd.PersistentFlags().Lookup("minor")); err != nil {
 		log.Fatal(err)
 	}
 
-	rootCmd.Flags().Bool("patch", true, "bump patch version")
-	if err := viper.BindPFlag("patch", rootCmd.Flags().Lookup("patch")); err != nil {
+	rootCmd.PersistentFlags().Bool("patch", true, "bump patch version")
+	if err := viper.BindPFlag("patch", rootCmd.PersistentFlags().Lookup("patch")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("auto", false, "infer the bump from conventional commits since the last tag")
+	if err := viper.BindPFlag("auto", rootCmd.PersistentFlags().Lookup("auto")); err != nil {
 		log.Fatal(err)
 	}
 
@@ -69,8 +222,63 @@ func init() {
 		log.Fatal(err)
 	}
 
-	rootCmd.Flags().Bool("snapshot", false, "set snapshot version")
-	if err := viper.BindPFlag("snapshot", rootCmd.Flags().Lookup("snapshot")); err != nil {
+	rootCmd.PersistentFlags().StringSlice("channel", nil, "map branches to prerelease channels, e.g. main=beta or release/*=rc")
+	if err := viper.BindPFlag("channel", rootCmd.PersistentFlags().Lookup("channel")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("branch", "", "branch used to select the channel, defaults to the checked out branch")
+	if err := viper.BindPFlag("branch", rootCmd.PersistentFlags().Lookup("branch")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("snapshot", false, "set snapshot version")
+	if err := viper.BindPFlag("snapshot", rootCmd.PersistentFlags().Lookup("snapshot")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("describe", false, "describe HEAD by the number of commits since the last tag, e.g. 1.2.4-dev.17+g1a2b3c4")
+	if err := viper.BindPFlag("describe", rootCmd.PersistentFlags().Lookup("describe")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("pseudo", false, "print the Go module pseudo-version of HEAD, e.g. v1.2.4-0.20261017120000-abcdef123456")
+	if err := viper.BindPFlag("pseudo", rootCmd.PersistentFlags().Lookup("pseudo")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("release", false, "promote the highest prerelease to a final release, e.g. 1.4.0-rc3 to 1.4.0")
+	if err := viper.BindPFlag("release", rootCmd.PersistentFlags().Lookup("release")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("allow-descendant", false, "with --release, allow HEAD to be a descendant of the prerelease commit")
+	if err := viper.BindPFlag("allow-descendant", rootCmd.PersistentFlags().Lookup("allow-descendant")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("require-clean", false, "fail if the worktree has uncommitted changes or untracked files")
+	if err := viper.BindPFlag("require-clean", rootCmd.PersistentFlags().Lookup("require-clean")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("mark-dirty", false, "add dirty build metadata if the worktree has uncommitted changes, e.g. 1.2.4+dirty")
+	if err := viper.BindPFlag("mark-dirty", rootCmd.PersistentFlags().Lookup("mark-dirty")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("scheme", "semver", "versioning scheme of the tags, semver, pep440 or calver:LAYOUT, e.g. calver:YY.0M.MICRO")
+	if err := viper.BindPFlag("scheme", rootCmd.PersistentFlags().Lookup("scheme")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().StringSlice("host", nil, "map self-hosted git services to a provider for pull request links, e.g. git.example.com=gitea")
+	if err := viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("host")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().StringSlice("issue", nil, "link issue references matching a pattern, e.g. \"[A-Z]+-[0-9]+=https://jira.example.com/browse/{{.ID}}\"")
+	if err := viper.BindPFlag("issue", rootCmd.PersistentFlags().Lookup("issue")); err != nil {
 		log.Fatal(err)
 	}
 
@@ -79,15 +287,50 @@ func init() {
 		log.Fatal(err)
 	}
 
+	rootCmd.PersistentFlags().String("component", "", "use the prefix and paths of a component from the config file")
+	if err := viper.BindPFlag("component", rootCmd.PersistentFlags().Lookup("component")); err != nil {
+		log.Fatal(err)
+	}
+
 	rootCmd.PersistentFlags().String("below", "", "only look at tags below version")
 	if err := viper.BindPFlag("below", rootCmd.PersistentFlags().Lookup("below")); err != nil {
 		log.Fatal(err)
 	}
+
+	rootCmd.PersistentFlags().StringSlice("path", nil, "only consider commits touching these paths, for monorepo components")
+	if err := viper.BindPFlag("path", rootCmd.PersistentFlags().Lookup("path")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("first-parent", false, "only follow the first parent of merge commits in history and bump inference")
+	if err := viper.BindPFlag("first-parent", rootCmd.PersistentFlags().Lookup("first-parent")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("no-merges", false, "leave merge commits out of history and bump inference")
+	if err := viper.BindPFlag("no-merges", rootCmd.PersistentFlags().Lookup("no-merges")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("ref", "", "compute versions from ref instead of HEAD")
+	if err := viper.BindPFlag("ref", rootCmd.PersistentFlags().Lookup("ref")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("all-tags", false, "consider all tags, not only tags reachable from HEAD or ref")
+	if err := viper.BindPFlag("all-tags", rootCmd.PersistentFlags().Lookup("all-tags")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("within", "", "only look at tags within a version range, e.g. \">=2.0.0 <3.0.0\" or \"^2.1\"")
+	if err := viper.BindPFlag("within", rootCmd.PersistentFlags().Lookup("within")); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
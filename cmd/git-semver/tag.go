@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/softsense/git-semver/pkg/git"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	rootCmd.AddCommand(tagCmd)
+	tagCmd.Flags().BoolP("annotate", "a", false, "create an annotated tag")
+	if err := viper.BindPFlag("tag.annotate", tagCmd.Flags().Lookup("annotate")); err != nil {
+		log.Fatal(err)
+	}
+	tagCmd.Flags().StringP("message", "m", git.DefaultTagMessage, "message template for annotated tags, {{.Version}} and {{.Previous}} are available")
+	if err := viper.BindPFlag("tag.message", tagCmd.Flags().Lookup("message")); err != nil {
+		log.Fatal(err)
+	}
+	tagCmd.Flags().String("push", "", "push the tag to the named remote")
+	if err := viper.BindPFlag("tag.push", tagCmd.Flags().Lookup("push")); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Tag HEAD with the next version.",
+	Run: func(cmd *cobra.Command, args []string) {
+		g, err := openRepo()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		n, err := nextVersion(g)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		v, err := newVersionOutput(g, n)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ref, err := g.CreateTag(n, git.TagOptions{
+			Annotated: viper.GetBool("tag.annotate"),
+			Message:   viper.GetString("tag.message"),
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		remote := viper.GetString("tag.push")
+		if remote != "" {
+			if err := g.PushTag(remote, ref); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		if jsonOutput() {
+			out := tagOutput{versionOutput: v, Tag: ref.Name().Short(), Pushed: remote != ""}
+			if err := printJSON(out); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		fmt.Println(g.Format(n))
+	},
+}
@@ -0,0 +1,233 @@
+// Package calver implements calendar versioning, see https://calver.org.
+// Versions are represented as semver.Version with the three segments of a
+// layout in Major, Minor and Patch, so that they are ordered like semantic
+// versions.
+package calver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/softsense/git-semver/pkg/semver"
+)
+
+// token is a segment of a layout.
+type token string
+
+const (
+	fullYear   token = "YYYY"
+	shortYear  token = "YY"
+	paddedYear token = "0Y"
+	month      token = "MM"
+	paddedMon  token = "0M"
+	week       token = "WW"
+	paddedWeek token = "0W"
+	day        token = "DD"
+	paddedDay  token = "0D"
+	micro      token = "MICRO"
+)
+
+var tokens = []token{fullYear, shortYear, paddedYear, month, paddedMon, week, paddedWeek, day, paddedDay, micro}
+
+// Layout is a calendar versioning format of three dot separated segments,
+// e.g. YYYY.MM.MICRO or YY.0M.MICRO. The first segment is a year, YYYY, YY
+// or 0Y, MICRO may only be the last segment and the others are MM, 0M, WW,
+// 0W, DD or 0D. Padded segments start with 0.
+type Layout struct {
+	segments [3]token
+}
+
+// ParseLayout parses a layout such as "YYYY.0M.MICRO".
+func ParseLayout(s string) (Layout, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return Layout{}, fmt.Errorf("invalid calver layout %q, expected three segments", s)
+	}
+
+	var l Layout
+	for i, p := range parts {
+		t, ok := parseToken(p)
+		if !ok {
+			return Layout{}, fmt.Errorf("invalid calver layout %q: unknown segment %q", s, p)
+		}
+		switch {
+		case i == 0 && !t.year():
+			return Layout{}, fmt.Errorf("invalid calver layout %q: must start with a year", s)
+		case i > 0 && t.year():
+			return Layout{}, fmt.Errorf("invalid calver layout %q: only the first segment can be a year", s)
+		case i < 2 && t == micro:
+			return Layout{}, fmt.Errorf("invalid calver layout %q: MICRO must be the last segment", s)
+		}
+		l.segments[i] = t
+	}
+	return l, nil
+}
+
+// MustParseLayout is like ParseLayout but panics if the layout is invalid.
+func MustParseLayout(s string) Layout {
+	l, err := ParseLayout(s)
+	if err != nil {
+		panic(`calver: ParseLayout(` + s + `): ` + err.Error())
+	}
+	return l
+}
+
+func (l Layout) String() string {
+	return fmt.Sprintf("%s.%s.%s", l.segments[0], l.segments[1], l.segments[2])
+}
+
+// Parse parses a version in the layout, with an optional prefix like
+// semver.Parse, prerelease and build metadata.
+func (l Layout) Parse(s string) (semver.Version, error) {
+	prefix, rest := semver.SplitPrefix(s)
+
+	core, suffix := rest, ""
+	if i := strings.IndexAny(rest, "-+"); i != -1 {
+		core, suffix = rest[:i], rest[i:]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return semver.Version{}, fmt.Errorf("%q does not match %s", s, l)
+	}
+
+	var nums [3]uint64
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil || l.segments[i].format(n) != p || !l.segments[i].valid(n) {
+			return semver.Version{}, fmt.Errorf("%q does not match %s: invalid %s %q", s, l, l.segments[i], p)
+		}
+		nums[i] = n
+	}
+
+	return semver.Parse(fmt.Sprintf("%s%d.%d.%d%s", prefix, nums[0], nums[1], nums[2], suffix))
+}
+
+// Format returns v in the layout, with the prefix, prerelease and build
+// metadata of v.
+func (l Layout) Format(v semver.Version) string {
+	core := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	bare := v
+	bare.Prefix = ""
+	suffix := strings.TrimPrefix(bare.String(), core)
+
+	return fmt.Sprintf("%s%s.%s.%s%s", v.Prefix,
+		l.segments[0].format(v.Major),
+		l.segments[1].format(v.Minor),
+		l.segments[2].format(v.Patch),
+		suffix,
+	)
+}
+
+// Next returns the version following v at time now. The date segments are
+// set from now and MICRO is incremented if they did not change, or reset to
+// 0 if they did. Layouts with a week segment take the year of the ISO week,
+// so 2027-01-01 is in week 53 of 2026. The prefix, prerelease and build
+// metadata of v are kept. It fails if now is before the date of v, or if the
+// date did not change and the layout has no MICRO segment.
+func (l Layout) Next(v semver.Version, now time.Time) (semver.Version, error) {
+	prev := [3]uint64{v.Major, v.Minor, v.Patch}
+	next := prev
+	changed := false
+	year := now.Year()
+	if l.weekly() {
+		year, _ = now.ISOWeek()
+	}
+	for i, t := range l.segments {
+		if t == micro {
+			continue
+		}
+		next[i] = t.value(now, year)
+		if next[i] != prev[i] && !changed {
+			if next[i] < prev[i] {
+				return semver.Version{}, fmt.Errorf("date %s is before version %s", now.Format("2006-01-02"), l.Format(v))
+			}
+			changed = true
+		}
+	}
+
+	switch {
+	case l.segments[2] == micro && changed:
+		next[2] = 0
+	case l.segments[2] == micro:
+		next[2]++
+	case !changed:
+		return semver.Version{}, fmt.Errorf("version %s is already released for %s, %s has no MICRO segment", l.Format(v), now.Format("2006-01-02"), l)
+	}
+
+	v.Major, v.Minor, v.Patch = next[0], next[1], next[2]
+	return v, nil
+}
+
+// weekly checks if the layout has a week segment.
+func (l Layout) weekly() bool {
+	for _, t := range l.segments {
+		if t == week || t == paddedWeek {
+			return true
+		}
+	}
+	return false
+}
+
+func parseToken(s string) (token, bool) {
+	for _, t := range tokens {
+		if string(t) == s {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+func (t token) year() bool {
+	return t == fullYear || t == shortYear || t == paddedYear
+}
+
+func (t token) padded() bool {
+	return strings.HasPrefix(string(t), "0")
+}
+
+// value returns the segment for the date of now, with the year segments
+// set to year.
+func (t token) value(now time.Time, year int) uint64 {
+	switch t {
+	case fullYear:
+		return uint64(year)
+	case shortYear, paddedYear:
+		return uint64(year - 2000)
+	case month, paddedMon:
+		return uint64(now.Month())
+	case week, paddedWeek:
+		_, w := now.ISOWeek()
+		return uint64(w)
+	case day, paddedDay:
+		return uint64(now.Day())
+	default:
+		return 0
+	}
+}
+
+func (t token) format(n uint64) string {
+	if t.padded() {
+		return fmt.Sprintf("%02d", n)
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+// valid checks if n is in the range of the segment.
+func (t token) valid(n uint64) bool {
+	switch t {
+	case fullYear:
+		return n >= 1000
+	case shortYear, paddedYear:
+		return n < 1000
+	case month, paddedMon:
+		return n >= 1 && n <= 12
+	case week, paddedWeek:
+		return n >= 1 && n <= 53
+	case day, paddedDay:
+		return n >= 1 && n <= 31
+	default:
+		return true
+	}
+}
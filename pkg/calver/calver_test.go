@@ -0,0 +1,112 @@
+package calver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		layout  string
+		wantErr string
+	}{
+		{layout: "YYYY.MM.MICRO"},
+		{layout: "YY.0M.MICRO"},
+		{layout: "0Y.0W.MICRO"},
+		{layout: "YYYY.0M.0D"},
+		{layout: "YYYY.MM", wantErr: `invalid calver layout "YYYY.MM", expected three segments`},
+		{layout: "YYYY.MM.DD.MICRO", wantErr: `invalid calver layout "YYYY.MM.DD.MICRO", expected three segments`},
+		{layout: "MM.YYYY.MICRO", wantErr: `invalid calver layout "MM.YYYY.MICRO": must start with a year`},
+		{layout: "YYYY.YY.MICRO", wantErr: `invalid calver layout "YYYY.YY.MICRO": only the first segment can be a year`},
+		{layout: "YYYY.MICRO.MM", wantErr: `invalid calver layout "YYYY.MICRO.MM": MICRO must be the last segment`},
+		{layout: "YYYY.MM.PATCH", wantErr: `invalid calver layout "YYYY.MM.PATCH": unknown segment "PATCH"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			l, err := ParseLayout(tt.layout)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.layout, l.String())
+		})
+	}
+}
+
+func TestLayout_Parse(t *testing.T) {
+	tests := []struct {
+		layout  string
+		version string
+		expect  string
+		wantErr string
+	}{
+		{layout: "YYYY.MM.MICRO", version: "2026.10.3", expect: "2026.10.3"},
+		{layout: "YYYY.MM.MICRO", version: "v2026.1.0", expect: "v2026.1.0"},
+		{layout: "YY.0M.MICRO", version: "26.01.0", expect: "26.1.0"},
+		{layout: "YY.0M.MICRO", version: "api/v26.10.2-rc.1+b5", expect: "api/v26.10.2-rc.1+b5"},
+		{layout: "0Y.0M.0D", version: "06.02.03", expect: "6.2.3"},
+		{layout: "YY.0M.MICRO", version: "26.1.0", wantErr: `"26.1.0" does not match YY.0M.MICRO: invalid 0M "1"`},
+		{layout: "YYYY.MM.MICRO", version: "2026.01.0", wantErr: `"2026.01.0" does not match YYYY.MM.MICRO: invalid MM "01"`},
+		{layout: "YYYY.MM.MICRO", version: "2026.13.0", wantErr: `"2026.13.0" does not match YYYY.MM.MICRO: invalid MM "13"`},
+		{layout: "YYYY.MM.MICRO", version: "1.2.3", wantErr: `"1.2.3" does not match YYYY.MM.MICRO: invalid YYYY "1"`},
+		{layout: "YY.0M.MICRO", version: "2026.10.3", wantErr: `"2026.10.3" does not match YY.0M.MICRO: invalid YY "2026"`},
+		{layout: "YYYY.MM.MICRO", version: "2026.10", wantErr: `"2026.10" does not match YYYY.MM.MICRO`},
+		{layout: "YYYY.MM.MICRO", version: "2026.10.01", wantErr: `"2026.10.01" does not match YYYY.MM.MICRO: invalid MICRO "01"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := MustParseLayout(tt.layout).Parse(tt.version)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, semver.MustParse(tt.expect), v)
+			require.Equal(t, tt.version, MustParseLayout(tt.layout).Format(v))
+		})
+	}
+}
+
+func TestLayout_Next(t *testing.T) {
+	oct3 := time.Date(2026, 10, 3, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		layout  string
+		version string
+		now     time.Time
+		expect  string
+		wantErr string
+	}{
+		{layout: "YYYY.MM.MICRO", version: "0.0.0", now: oct3, expect: "2026.10.0"},
+		{layout: "YYYY.MM.MICRO", version: "v2026.10.3", now: oct3, expect: "v2026.10.4"},
+		{layout: "YYYY.MM.MICRO", version: "2026.9.7", now: oct3, expect: "2026.10.0"},
+		{layout: "YYYY.MM.MICRO", version: "2025.12.7", now: oct3, expect: "2026.10.0"},
+		{layout: "YY.0M.MICRO", version: "26.10.0", now: oct3, expect: "26.10.1"},
+		{layout: "YY.0M.MICRO", version: "26.10.0-rc.1", now: oct3, expect: "26.10.1-rc.1"},
+		{layout: "YYYY.0W.MICRO", version: "2026.39.2", now: oct3, expect: "2026.40.0"},
+		{layout: "YYYY.WW.MICRO", version: "2026.52.1", now: time.Date(2027, 1, 1, 12, 0, 0, 0, time.UTC), expect: "2026.53.0"},
+		{layout: "YYYY.WW.MICRO", version: "2026.53.0", now: time.Date(2027, 1, 5, 12, 0, 0, 0, time.UTC), expect: "2027.1.0"},
+		{layout: "YY.0W.MICRO", version: "26.53.0", now: time.Date(2027, 1, 3, 12, 0, 0, 0, time.UTC), expect: "26.53.1"},
+		{layout: "YYYY.0M.0D", version: "2026.10.2", now: oct3, expect: "2026.10.3"},
+		{layout: "YYYY.0M.0D", version: "2026.10.3", now: oct3, wantErr: "version 2026.10.03 is already released for 2026-10-03, YYYY.0M.0D has no MICRO segment"},
+		{layout: "YYYY.MM.MICRO", version: "2026.11.0", now: oct3, wantErr: "date 2026-10-03 is before version 2026.11.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout+"/"+tt.version, func(t *testing.T) {
+			v, err := MustParseLayout(tt.layout).Next(semver.MustParse(tt.version), tt.now)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, semver.MustParse(tt.expect), v)
+		})
+	}
+}
@@ -0,0 +1,368 @@
+// Package config reads the repository configuration file, .git-semver.yaml.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/softsense/git-semver/pkg/git"
+	"github.com/softsense/git-semver/pkg/hosting"
+	"github.com/softsense/git-semver/pkg/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile is the name of the configuration file looked up in the
+// repository root.
+const DefaultFile = ".git-semver.yaml"
+
+var (
+	yamlErrorLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	unknownField  = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+)
+
+// File is the content of a configuration file. Keys are named like the
+// command line flags.
+type File struct {
+	Scheme          string    `yaml:"scheme"`
+	Prefix          string    `yaml:"prefix"`
+	Below           string    `yaml:"below"`
+	Within          string    `yaml:"within"`
+	AllTags         bool      `yaml:"all-tags"`
+	AllowDescendant bool      `yaml:"allow-descendant"`
+	RequireClean    bool      `yaml:"require-clean"`
+	MarkDirty       bool      `yaml:"mark-dirty"`
+	FirstParent     bool      `yaml:"first-parent"`
+	NoMerges        bool      `yaml:"no-merges"`
+	Paths           []string  `yaml:"paths"`
+	Channels        []Channel `yaml:"channels"`
+	Hosts           []Host    `yaml:"hosts"`
+	Issues          []Issue   `yaml:"issues"`
+	Bump            Bump      `yaml:"bump"`
+	Changelog       Changelog `yaml:"changelog"`
+	Tag             Tag       `yaml:"tag"`
+
+	// Components of a monorepo by name, selected with --component
+	Components map[string]Component `yaml:"components"`
+}
+
+// Channel maps branches to a prerelease identifier, see git.Channel.
+type Channel struct {
+	Branch     string `yaml:"branch"`
+	Identifier string `yaml:"identifier"`
+}
+
+// Host maps a self-hosted git service to a provider and optionally a pull
+// request URL template, see hosting.Host.
+type Host struct {
+	Host     string `yaml:"host"`
+	Provider string `yaml:"provider"`
+	Template string `yaml:"template"`
+}
+
+func (h Host) hosting() hosting.Host {
+	return hosting.Host{Name: strings.ToLower(h.Host), Provider: hosting.Provider(strings.ToLower(h.Provider)), Template: h.Template}
+}
+
+// Issue links issue references matching Pattern to the URL rendered from
+// Template, see hosting.NewIssueTracker.
+type Issue struct {
+	Pattern  string `yaml:"pattern"`
+	Template string `yaml:"template"`
+}
+
+// Component is a separately versioned part of a monorepo, with its own tag
+// prefix and the paths of its commits.
+type Component struct {
+	Prefix string   `yaml:"prefix"`
+	Paths  []string `yaml:"paths"`
+}
+
+// Bump configures how the bump is decided.
+type Bump struct {
+	// Auto infers the bump from conventional commits
+	Auto bool `yaml:"auto"`
+
+	// Rules map conventional commit types to none, patch, minor or major
+	Rules map[string]string `yaml:"rules"`
+}
+
+// Changelog configures the changelog command.
+type Changelog struct {
+	File string `yaml:"file"`
+}
+
+// Tag configures the tag command.
+type Tag struct {
+	Annotate bool   `yaml:"annotate"`
+	Message  string `yaml:"message"`
+	Push     string `yaml:"push"`
+}
+
+// Load reads and validates the configuration file at path. Errors are
+// reported with the file name and line.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	return Parse(path, data)
+}
+
+// Parse parses and validates configuration data read from the file name.
+// Unknown keys and invalid values are reported with the file name and line.
+func Parse(name string, data []byte) (*File, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, yamlError(name, err)
+	}
+
+	// the decoder keeps going after unknown keys and type errors, so the
+	// values it did decode are validated too
+	var (
+		f    File
+		errs []error
+	)
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		errs = append(errs, yamlError(name, err))
+	}
+	errs = append(errs, f.validate(name, &root)...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return &f, nil
+}
+
+// Settings returns the configuration as a nested map keyed like the command
+// line flags, with zero values left out.
+func (f *File) Settings() map[string]interface{} {
+	out := make(map[string]interface{})
+	set := func(m map[string]interface{}, key string, v interface{}, zero bool) {
+		if !zero {
+			m[key] = v
+		}
+	}
+
+	set(out, "scheme", f.Scheme, f.Scheme == "")
+	set(out, "prefix", f.Prefix, f.Prefix == "")
+	set(out, "below", f.Below, f.Below == "")
+	set(out, "within", f.Within, f.Within == "")
+	set(out, "all-tags", f.AllTags, !f.AllTags)
+	set(out, "path", f.Paths, len(f.Paths) == 0)
+	set(out, "auto", f.Bump.Auto, !f.Bump.Auto)
+	set(out, "allow-descendant", f.AllowDescendant, !f.AllowDescendant)
+	set(out, "require-clean", f.RequireClean, !f.RequireClean)
+	set(out, "mark-dirty", f.MarkDirty, !f.MarkDirty)
+	set(out, "first-parent", f.FirstParent, !f.FirstParent)
+	set(out, "no-merges", f.NoMerges, !f.NoMerges)
+
+	channels := make([]string, 0, len(f.Channels))
+	for _, c := range f.Channels {
+		channels = append(channels, c.Branch+"="+c.Identifier)
+	}
+	set(out, "channel", channels, len(channels) == 0)
+
+	hosts := make([]string, 0, len(f.Hosts))
+	for _, h := range f.Hosts {
+		hosts = append(hosts, h.hosting().String())
+	}
+	set(out, "host", hosts, len(hosts) == 0)
+
+	issues := make([]string, 0, len(f.Issues))
+	for _, i := range f.Issues {
+		issues = append(issues, i.Pattern+"="+i.Template)
+	}
+	set(out, "issue", issues, len(issues) == 0)
+
+	bump := make(map[string]interface{})
+	set(bump, "rules", f.Bump.Rules, len(f.Bump.Rules) == 0)
+	set(out, "bump", bump, len(bump) == 0)
+
+	changelog := make(map[string]interface{})
+	set(changelog, "file", f.Changelog.File, f.Changelog.File == "")
+	set(out, "changelog", changelog, len(changelog) == 0)
+
+	tag := make(map[string]interface{})
+	set(tag, "annotate", f.Tag.Annotate, !f.Tag.Annotate)
+	set(tag, "message", f.Tag.Message, f.Tag.Message == "")
+	set(tag, "push", f.Tag.Push, f.Tag.Push == "")
+	set(out, "tag", tag, len(tag) == 0)
+
+	return out
+}
+
+// WithComponent returns a copy of f with the prefix and paths of the
+// component name.
+func (f *File) WithComponent(name string) (*File, error) {
+	c, ok := f.Components[name]
+	if !ok {
+		if len(f.Components) == 0 {
+			return nil, fmt.Errorf("unknown component %q, no components are configured", name)
+		}
+		return nil, fmt.Errorf("unknown component %q, expected %s", name, strings.Join(f.componentNames(), ", "))
+	}
+	out := *f
+	out.Prefix, out.Paths = c.Prefix, c.Paths
+	return &out, nil
+}
+
+// componentNames returns the names of the components in order.
+func (f *File) componentNames() []string {
+	names := make([]string, 0, len(f.Components))
+	for n := range f.Components {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BumpRules returns the bump rules parsed into git.Bump values.
+func BumpRules(rules map[string]string) (map[string]git.Bump, error) {
+	out := make(map[string]git.Bump, len(rules))
+	for typ, s := range rules {
+		b, err := git.ParseBump(s)
+		if err != nil {
+			return nil, fmt.Errorf("bump rule for %s: %w", typ, err)
+		}
+		out[typ] = b
+	}
+	return out, nil
+}
+
+func (f *File) validate(name string, root *yaml.Node) []error {
+	var errs []error
+	fail := func(err error, path ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s:%d: %s: %w", name, line(root, path...), keyPath(path), err))
+	}
+
+	scheme, err := git.ParseScheme(f.Scheme)
+	if err != nil {
+		fail(err, "scheme")
+		scheme = git.SemVer{}
+	}
+	if f.Below != "" {
+		if _, err := scheme.Parse(f.Below); err != nil {
+			fail(err, "below")
+		}
+	}
+	if f.Within != "" {
+		if _, err := semver.ParseRange(f.Within); err != nil {
+			fail(err, "within")
+		}
+	}
+	for i, c := range f.Channels {
+		if _, err := git.ParseChannel(c.Branch + "=" + c.Identifier); err != nil {
+			fail(err, "channels", i)
+		}
+	}
+	for i, h := range f.Hosts {
+		if err := h.hosting().Validate(); err != nil {
+			fail(err, "hosts", i)
+		}
+	}
+	for i, issue := range f.Issues {
+		if strings.Contains(issue.Pattern, "=") {
+			fail(fmt.Errorf("issue pattern %q cannot contain \"=\", use \\x3d", issue.Pattern), "issues", i)
+		} else if _, err := hosting.ParseIssueTracker(issue.Pattern + "=" + issue.Template); err != nil {
+			fail(err, "issues", i)
+		}
+	}
+	prefixes := make(map[string]string, len(f.Components))
+	for _, n := range f.componentNames() {
+		c := f.Components[n]
+		if c.Prefix == "" {
+			fail(errors.New("component has no prefix"), "components", n)
+		} else if other, ok := prefixes[c.Prefix]; ok {
+			fail(fmt.Errorf("prefix %q is also used by component %s", c.Prefix, other), "components", n, "prefix")
+		}
+		prefixes[c.Prefix] = n
+	}
+	for typ, s := range f.Bump.Rules {
+		if _, err := git.ParseBump(s); err != nil {
+			fail(err, "bump", "rules", typ)
+		}
+	}
+	if f.Tag.Message != "" {
+		if _, err := template.New("message").Parse(f.Tag.Message); err != nil {
+			fail(err, "tag", "message")
+		}
+	}
+
+	return errs
+}
+
+// line returns the line of the node at path, made of mapping keys and
+// sequence indexes, or the line of the deepest node found.
+func line(n *yaml.Node, path ...interface{}) int {
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	for _, p := range path {
+		var next *yaml.Node
+		switch key := p.(type) {
+		case string:
+			for i := 0; n.Kind == yaml.MappingNode && i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value == key {
+					next = n.Content[i+1]
+				}
+			}
+		case int:
+			if n.Kind == yaml.SequenceNode && key < len(n.Content) {
+				next = n.Content[key]
+			}
+		}
+		if next == nil {
+			break
+		}
+		n = next
+	}
+	return n.Line
+}
+
+func keyPath(path []interface{}) string {
+	parts := make([]string, 0, len(path))
+	for _, p := range path {
+		switch key := p.(type) {
+		case int:
+			parts[len(parts)-1] += "[" + strconv.Itoa(key) + "]"
+		default:
+			parts = append(parts, fmt.Sprint(key))
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// yamlError rewrites errors from the YAML decoder to "name:line: message".
+func yamlError(name string, err error) error {
+	var te *yaml.TypeError
+	if !errors.As(err, &te) {
+		if m := yamlErrorLine.FindStringSubmatch(err.Error()); m != nil {
+			return fmt.Errorf("%s:%s: %s", name, m[1], m[2])
+		}
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	errs := make([]error, 0, len(te.Errors))
+	for _, msg := range te.Errors {
+		m := yamlErrorLine.FindStringSubmatch(msg)
+		if m == nil {
+			errs = append(errs, fmt.Errorf("%s: %s", name, msg))
+			continue
+		}
+		if u := unknownField.FindStringSubmatch(m[2]); u != nil {
+			m[2] = fmt.Sprintf("unknown key %q", u[1])
+		}
+		errs = append(errs, fmt.Errorf("%s:%s: %s", name, m[1], m[2]))
+	}
+	return errors.Join(errs...)
+}
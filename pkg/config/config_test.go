@@ -0,0 +1,254 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/softsense/git-semver/pkg/git"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	data := []byte(`prefix: v
+below: v2.0.0
+within: ">=1.0.0 <2.0.0"
+all-tags: true
+allow-descendant: true
+require-clean: true
+mark-dirty: true
+first-parent: true
+no-merges: true
+paths:
+  - api
+channels:
+  - branch: main
+    identifier: beta
+  - branch: release/*
+    identifier: rc
+hosts:
+  - host: git.example.com
+    provider: gitea
+  - host: gitlab.example.com
+    provider: gitlab
+    template: "https://review.example.com/{{.Path}}/{{.Number}}"
+issues:
+  - pattern: '\bPROJ-[0-9]+\b'
+    template: "https://jira.example.com/browse/{{.ID}}"
+bump:
+  auto: true
+  rules:
+    perf: minor
+changelog:
+  file: CHANGELOG.md
+tag:
+  annotate: true
+  message: "Release {{.Version}}"
+  push: origin
+components:
+  web:
+    prefix: web/v
+    paths:
+      - web
+`)
+
+	f, err := Parse(DefaultFile, data)
+	require.NoError(t, err)
+	require.Equal(t, &File{
+		Prefix:          "v",
+		Below:           "v2.0.0",
+		Within:          ">=1.0.0 <2.0.0",
+		AllTags:         true,
+		Paths:           []string{"api"},
+		AllowDescendant: true,
+		RequireClean:    true,
+		MarkDirty:       true,
+		FirstParent:     true,
+		NoMerges:        true,
+		Channels:        []Channel{{Branch: "main", Identifier: "beta"}, {Branch: "release/*", Identifier: "rc"}},
+		Hosts: []Host{
+			{Host: "git.example.com", Provider: "gitea"},
+			{Host: "gitlab.example.com", Provider: "gitlab", Template: "https://review.example.com/{{.Path}}/{{.Number}}"},
+		},
+		Issues: []Issue{{Pattern: `\bPROJ-[0-9]+\b`, Template: "https://jira.example.com/browse/{{.ID}}"}},
+		Bump: Bump{
+			Auto:  true,
+			Rules: map[string]string{"perf": "minor"},
+		},
+		Changelog:  Changelog{File: "CHANGELOG.md"},
+		Tag:        Tag{Annotate: true, Message: "Release {{.Version}}", Push: "origin"},
+		Components: map[string]Component{"web": {Prefix: "web/v", Paths: []string{"web"}}},
+	}, f)
+
+	require.Equal(t, map[string]interface{}{
+		"prefix":           "v",
+		"below":            "v2.0.0",
+		"within":           ">=1.0.0 <2.0.0",
+		"all-tags":         true,
+		"allow-descendant": true,
+		"require-clean":    true,
+		"mark-dirty":       true,
+		"first-parent":     true,
+		"no-merges":        true,
+		"path":             []string{"api"},
+		"auto":             true,
+		"channel":          []string{"main=beta", "release/*=rc"},
+		"issue":            []string{`\bPROJ-[0-9]+\b=https://jira.example.com/browse/{{.ID}}`},
+		"host":             []string{"git.example.com=gitea", "gitlab.example.com=gitlab=https://review.example.com/{{.Path}}/{{.Number}}"},
+		"bump":             map[string]interface{}{"rules": map[string]string{"perf": "minor"}},
+		"changelog":        map[string]interface{}{"file": "CHANGELOG.md"},
+		"tag":              map[string]interface{}{"annotate": true, "message": "Release {{.Version}}", "push": "origin"},
+	}, f.Settings())
+
+	rules, err := BumpRules(f.Bump.Rules)
+	require.NoError(t, err)
+	require.Equal(t, map[string]git.Bump{"perf": git.BumpMinor}, rules)
+}
+
+func TestWithComponent(t *testing.T) {
+	f, err := Parse(DefaultFile, []byte(`prefix: v
+paths:
+  - cmd
+components:
+  api:
+    prefix: api/v
+    paths:
+      - api
+      - proto
+  web:
+    prefix: web/v
+`))
+	require.NoError(t, err)
+
+	api, err := f.WithComponent("api")
+	require.NoError(t, err)
+	require.Equal(t, "api/v", api.Settings()["prefix"])
+	require.Equal(t, []string{"api", "proto"}, api.Settings()["path"])
+	require.Equal(t, "v", f.Prefix)
+
+	web, err := f.WithComponent("web")
+	require.NoError(t, err)
+	require.Equal(t, "web/v", web.Settings()["prefix"])
+	require.NotContains(t, web.Settings(), "path")
+
+	_, err = f.WithComponent("db")
+	require.EqualError(t, err, `unknown component "db", expected api, web`)
+
+	_, err = (&File{}).WithComponent("db")
+	require.EqualError(t, err, `unknown component "db", no components are configured`)
+}
+
+func TestParseEmpty(t *testing.T) {
+	f, err := Parse(DefaultFile, nil)
+	require.NoError(t, err)
+	require.Equal(t, &File{}, f)
+	require.Empty(t, f.Settings())
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "unknown key",
+			data: "prefix: v\nprefx: v\n",
+			want: `.git-semver.yaml:2: unknown key "prefx"`,
+		},
+		{
+			name: "unknown nested key",
+			data: "tag:\n  annotate: true\n  mesage: foo\n",
+			want: `.git-semver.yaml:3: unknown key "mesage"`,
+		},
+		{
+			name: "wrong type",
+			data: "prefix: v\nall-tags: maybe\n",
+			want: ".git-semver.yaml:2: cannot unmarshal !!str `maybe` into bool",
+		},
+		{
+			name: "syntax",
+			data: "prefix: v\n  below: [\n",
+			want: ".git-semver.yaml:2: mapping values are not allowed in this context",
+		},
+		{
+			name: "invalid version",
+			data: "prefix: v\nbelow: two\n",
+			want: ".git-semver.yaml:2: below: no Major.Minor.Patch elements found",
+		},
+		{
+			name: "invalid scheme",
+			data: "prefix: v\nscheme: calver:MM.YYYY.MICRO\n",
+			want: `.git-semver.yaml:2: scheme: invalid calver layout "MM.YYYY.MICRO": must start with a year`,
+		},
+		{
+			name: "calver version",
+			data: "scheme: calver:YY.0M.MICRO\nbelow: 26.1.0\n",
+			want: `.git-semver.yaml:2: below: "26.1.0" does not match YY.0M.MICRO: invalid 0M "1"`,
+		},
+		{
+			name: "invalid range",
+			data: "within: \">=1.x.2\"\n",
+			want: `.git-semver.yaml:1: within: parse range ">=1.x.2": version part "2" follows a wildcard in "1.x.2"`,
+		},
+		{
+			name: "invalid channel",
+			data: "channels:\n  - branch: main\n    identifier: beta\n  - branch: release/*\n",
+			want: `.git-semver.yaml:4: channels[1]: invalid channel "release/*=", expected branch=identifier`,
+		},
+		{
+			name: "invalid host",
+			data: "hosts:\n  - host: git.example.com\n    provider: gogs\n",
+			want: `.git-semver.yaml:2: hosts[0]: invalid git host provider "gogs", expected github, gitlab, bitbucket, gitea or azure`,
+		},
+		{
+			name: "invalid issue pattern",
+			data: "issues:\n  - pattern: \"PROJ-[0-9\"\n    template: \"https://jira.example.com/browse/{{.ID}}\"\n",
+			want: ".git-semver.yaml:2: issues[0]: parse issue pattern: error parsing regexp: missing closing ]: `[0-9`",
+		},
+		{
+			name: "invalid bump rule",
+			data: "bump:\n  rules:\n    feat: huge\n",
+			want: `.git-semver.yaml:3: bump.rules.feat: invalid bump "huge", expected none, patch, minor or major`,
+		},
+		{
+			name: "invalid template",
+			data: "tag:\n  message: \"{{.Version\"\n",
+			want: `.git-semver.yaml:2: tag.message: template: message:1: unclosed action`,
+		},
+		{
+			name: "component without prefix",
+			data: "components:\n  api:\n    paths: [api]\n",
+			want: `.git-semver.yaml:3: components.api: component has no prefix`,
+		},
+		{
+			name: "duplicate component prefix",
+			data: "components:\n  api:\n    prefix: v\n  web:\n    prefix: v\n",
+			want: `.git-semver.yaml:5: components.web.prefix: prefix "v" is also used by component api`,
+		},
+		{
+			name: "several errors",
+			data: "below: two\nfoo: bar\n",
+			want: ".git-semver.yaml:2: unknown key \"foo\"\n.git-semver.yaml:1: below: no Major.Minor.Patch elements found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(DefaultFile, []byte(tt.data))
+			require.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	require.NoError(t, os.WriteFile(path, []byte("prefix: v\n"), 0o644))
+
+	f, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, "v", f.Prefix)
+
+	_, err = Load(filepath.Join(t.TempDir(), DefaultFile))
+	require.Error(t, err)
+}
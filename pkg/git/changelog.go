@@ -0,0 +1,242 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+const changelogHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.0.0/).
+`
+
+var changelogRelease = regexp.MustCompile(`^## \[([^\]]+)\]`)
+
+// changelogGroups are the sections of a release in the order they are
+// rendered, keyed by conventional commit type. Commits of other types and
+// commits that are not conventional go into "Other Changes".
+var changelogGroups = []struct {
+	title string
+	types []string
+}{
+	{"Features", []string{"feat"}},
+	{"Bug Fixes", []string{"fix"}},
+	{"Performance Improvements", []string{"perf"}},
+	{"Reverts", []string{"revert"}},
+	{"Documentation", []string{"docs"}},
+	{"Refactoring", []string{"refactor"}},
+}
+
+const (
+	changelogBreaking = "Breaking Changes"
+	changelogOther    = "Other Changes"
+	changelogIssues   = "Referenced Issues"
+)
+
+// Changelog renders a Keep a Changelog style Markdown section for version v
+// released at date, with the commits of the release grouped by conventional
+// commit type, followed by the referenced issues. If v is already tagged the
+// section has the commits since the previous version up to its tag,
+// otherwise the commits since the highest version. A v without prefix gets
+// the configured one.
+func (g *Git) Changelog(v semver.Version, date time.Time) (string, error) {
+	if v.Prefix == "" {
+		v.Prefix = g.cfg.Prefix
+	}
+	commits, err := g.releaseCommits(v)
+	if err != nil {
+		return "", err
+	}
+
+	repo := g.hosting()
+	groups := make(map[string][]string)
+	referencing := make([]Commit, 0, len(commits))
+	for _, c := range commits {
+		hash := c.Hash.String()[:7]
+		issues, err := g.issues(c, repo)
+		if err != nil {
+			return "", err
+		}
+		referencing = append(referencing, Commit{Hash: c.Hash.String(), Issues: issues})
+
+		cc, ok := ParseConventionalCommit(c.Message)
+		if !ok {
+			subject, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+			groups[changelogOther] = append(groups[changelogOther], fmt.Sprintf("- %s %s", hash, subject))
+			continue
+		}
+
+		desc := cc.Description
+		if cc.Scope != "" {
+			desc = fmt.Sprintf("**%s:** %s", cc.Scope, desc)
+		}
+		entry := fmt.Sprintf("- %s %s", hash, desc)
+
+		if cc.Breaking {
+			note := entry
+			if cc.BreakingNote != "" {
+				note = fmt.Sprintf("- %s %s", hash, cc.BreakingNote)
+			}
+			groups[changelogBreaking] = append(groups[changelogBreaking], note)
+		}
+		groups[changelogGroup(cc.Type)] = append(groups[changelogGroup(cc.Type)], entry)
+	}
+
+	titles := []string{changelogBreaking}
+	for _, grp := range changelogGroups {
+		titles = append(titles, grp.title)
+	}
+	titles = append(titles, changelogOther)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## [%s] - %s\n", g.Format(v), date.Format("2006-01-02"))
+	for _, title := range titles {
+		entries := groups[title]
+		if len(entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", title)
+		for _, e := range entries {
+			b.WriteString(g.linkReferences(e + "\n"))
+		}
+	}
+
+	if issues := ReferencedIssues(referencing); len(issues) > 0 {
+		fmt.Fprintf(&b, "\n### %s\n\n", changelogIssues)
+		for _, issue := range issues {
+			fmt.Fprintf(&b, "- [%s](%s)\n", issue.ID, issue.URL)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// releaseCommits returns the commits of release v: the commits since the
+// highest version if v is not tagged, otherwise the commits since the
+// previous version up to the tag of v.
+func (g *Git) releaseCommits(v semver.Version) ([]*object.Commit, error) {
+	t, ok := g.versionTag(v)
+	if !ok {
+		commits, _, err := g.commitsSinceHighest()
+		return commits, err
+	}
+	to, err := g.tagCommitHash(t.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	prev, ok := g.previous(v)
+	if !ok {
+		return g.commitsRange(nil, to)
+	}
+	from, err := g.tagCommitHash(prev.ref)
+	if err != nil {
+		return nil, err
+	}
+	return g.commitsRange(&from, to)
+}
+
+// previous returns the highest tag below v. Like the highest version,
+// prereleases count only on the active channel, or if v is a prerelease.
+func (g *Git) previous(v semver.Version) (tagRef, bool) {
+	var (
+		prev  tagRef
+		found bool
+	)
+	for _, t := range g.tags {
+		if !t.version.LT(v) {
+			continue
+		}
+		if len(t.version.Pre) > 0 && len(v.Pre) == 0 && !g.onChannel(t.version) {
+			continue
+		}
+		if !found || t.version.GT(prev.version) {
+			prev, found = t, true
+		}
+	}
+	return prev, found
+}
+
+// TagDate returns the date of the tag for version v: the tagger date of an
+// annotated tag or the committer date of the tagged commit. The second
+// return value is false if there is no such tag.
+func (g *Git) TagDate(v semver.Version) (time.Time, bool, error) {
+	ref, ok := g.versionTag(v)
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	t, err := g.newTag(ref.version, ref.ref)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t.Date, true, nil
+}
+
+// PrependChangelog inserts a release section into the contents of an
+// existing changelog, above the previous releases and below the header and
+// any Unreleased section. An empty changelog gets a Keep a Changelog header.
+// It fails if the changelog already has a section for the release.
+func PrependChangelog(existing, section string) (string, error) {
+	if strings.TrimSpace(existing) == "" {
+		existing = changelogHeader
+	}
+	section = strings.TrimRight(section, "\n") + "\n"
+
+	var version string
+	if m := changelogRelease.FindStringSubmatch(section); m != nil {
+		version = m[1]
+	}
+
+	lines := strings.SplitAfter(existing, "\n")
+	insert := len(lines)
+	for i, l := range lines {
+		m := changelogRelease.FindStringSubmatch(l)
+		if m == nil {
+			continue
+		}
+		if m[1] == version {
+			return "", fmt.Errorf("changelog already has a section for %s", version)
+		}
+		if strings.EqualFold(m[1], "unreleased") || insert < len(lines) {
+			continue
+		}
+		insert = i
+	}
+
+	var b strings.Builder
+	for _, l := range lines[:insert] {
+		b.WriteString(l)
+	}
+	out := b.String()
+	if out != "" && !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	if out != "" && !strings.HasSuffix(out, "\n\n") {
+		out += "\n"
+	}
+	out += section
+	if insert < len(lines) {
+		out += "\n" + strings.Join(lines[insert:], "")
+	}
+
+	return out, nil
+}
+
+func changelogGroup(typ string) string {
+	for _, grp := range changelogGroups {
+		for _, t := range grp.types {
+			if t == typ {
+				return grp.title
+			}
+		}
+	}
+	return changelogOther
+}
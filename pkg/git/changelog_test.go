@@ -0,0 +1,212 @@
+package git
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/softsense/git-semver/pkg/hosting"
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func TestChangelog(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "feat: first", tag: "v1.0.0"},
+		testCommit{msg: "fix(parser): handle empty input (#3)"},
+		testCommit{msg: "feat: new config format\n\nBREAKING CHANGE: the old format is no longer read"},
+		testCommit{msg: "docs: readme"},
+		testCommit{msg: "Update dependencies"},
+	)
+	g, err := Open(path, Config{Prefix: "v"})
+	require.NoError(t, err)
+	_, err = g.repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"git@github.com:foo/bar.git"}})
+	require.NoError(t, err)
+
+	got, err := g.Changelog(semver.MustParse("v2.0.0"), time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	hashes := commitHashes(t, g)
+	want := strings.NewReplacer(
+		"H1", hashes[3], "H2", hashes[2], "H3", hashes[1], "H4", hashes[0],
+	).Replace(`## [v2.0.0] - 2026-10-17
+
+### Breaking Changes
+
+- H2 the old format is no longer read
+
+### Features
+
+- H2 new config format
+
+### Bug Fixes
+
+- H1 **parser:** handle empty input [(#3)](https://github.com/foo/bar/pull/3)
+
+### Documentation
+
+- H3 readme
+
+### Other Changes
+
+- H4 Update dependencies
+`)
+	require.Equal(t, want, got)
+}
+
+func TestChangelogIssues(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "feat: first", tag: "v1.0.0"},
+		testCommit{msg: "fix: PROJ-12 handle empty input\n\nFixes #4"},
+		testCommit{msg: "feat: PROJ-7 new format\n\nRelated to PROJ-12"},
+	)
+	jira, err := hosting.NewIssueTracker(`\b[A-Z][A-Z0-9]+-[0-9]+\b`, "https://jira.example.com/browse/{{.ID}}")
+	require.NoError(t, err)
+	fixes, err := hosting.NewIssueTracker(`(?i)\bfixes (?P<ref>#(?P<id>[0-9]+))`, "{{.URL}}/issues/{{.ID}}")
+	require.NoError(t, err)
+	g, err := Open(path, Config{Prefix: "v", Issues: []hosting.IssueTracker{jira, fixes}})
+	require.NoError(t, err)
+	_, err = g.repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"git@github.com:foo/bar.git"}})
+	require.NoError(t, err)
+
+	got, err := g.Changelog(semver.MustParse("v1.1.0"), time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	hashes := commitHashes(t, g)
+	want := strings.NewReplacer("H1", hashes[1], "H2", hashes[0]).Replace(`## [v1.1.0] - 2026-10-17
+
+### Features
+
+- H2 [PROJ-7](https://jira.example.com/browse/PROJ-7) new format
+
+### Bug Fixes
+
+- H1 [PROJ-12](https://jira.example.com/browse/PROJ-12) handle empty input
+
+### Referenced Issues
+
+- [PROJ-7](https://jira.example.com/browse/PROJ-7)
+- [PROJ-12](https://jira.example.com/browse/PROJ-12)
+- [4](https://github.com/foo/bar/issues/4)
+`)
+	require.Equal(t, want, got)
+}
+
+func TestChangelogTagged(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "feat: first", tag: "v1.0.0"},
+		testCommit{msg: "fix: parser"},
+		testCommit{msg: "chore: cfg", tag: "v1.1.0"},
+	)
+	g, err := Open(path, Config{Prefix: "v"})
+	require.NoError(t, err)
+
+	hashes := commitHashes(t, g)
+	want := strings.NewReplacer("H1", hashes[1], "H2", hashes[0]).Replace(`## [v1.1.0] - 2026-10-17
+
+### Bug Fixes
+
+- H1 parser
+
+### Other Changes
+
+- H2 cfg
+`)
+	for _, v := range []string{"v1.1.0", "1.1.0"} {
+		got, err := g.Changelog(semver.MustParse(v), time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Equal(t, want, got, v)
+	}
+
+	got, err := g.Changelog(semver.MustParse("v1.0.0"), time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, "## [v1.0.0] - 2026-10-17\n\n### Features\n\n- "+hashes[2]+" first\n", got)
+}
+
+func TestTagDate(t *testing.T) {
+	g, err := Open("testdata/repo", Config{Prefix: "v"})
+	require.NoError(t, err)
+
+	date, ok, err := g.TagDate(semver.MustParse("v0.0.2"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "2021-05-03", date.Format("2006-01-02"))
+
+	_, ok, err = g.TagDate(semver.MustParse("0.0.2"))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, ok, err = g.TagDate(semver.MustParse("v9.9.9"))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestPrependChangelog(t *testing.T) {
+	section := "## [1.1.0] - 2026-10-17\n\n### Features\n\n- abc1234 new\n"
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "empty",
+			existing: "",
+			want:     changelogHeader + "\n" + section,
+		},
+		{
+			name:     "previous release",
+			existing: "# Changelog\n\n## [1.0.0] - 2026-01-01\n\n- old\n",
+			want:     "# Changelog\n\n" + section + "\n## [1.0.0] - 2026-01-01\n\n- old\n",
+		},
+		{
+			name:     "below unreleased",
+			existing: "# Changelog\n\n## [Unreleased]\n\n- wip\n\n## [1.0.0] - 2026-01-01\n\n- old\n",
+			want:     "# Changelog\n\n## [Unreleased]\n\n- wip\n\n" + section + "\n## [1.0.0] - 2026-01-01\n\n- old\n",
+		},
+		{
+			name:     "only header",
+			existing: "# Changelog",
+			want:     "# Changelog\n\n" + section,
+		},
+		{
+			name:     "already released",
+			existing: "# Changelog\n\n## [1.1.0] - 2026-10-16\n\n- new\n",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PrependChangelog(tt.existing, section)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// commitHashes returns the short hashes of all commits from HEAD, newest
+// first.
+func commitHashes(t *testing.T, g *Git) []string {
+	t.Helper()
+
+	head, err := g.repo.Head()
+	require.NoError(t, err)
+	iter, err := g.repo.Log(&git.LogOptions{From: head.Hash()})
+	require.NoError(t, err)
+
+	var out []string
+	require.NoError(t, iter.ForEach(func(c *object.Commit) error {
+		out = append(out, c.Hash.String()[:7])
+		return nil
+	}))
+	return out
+}
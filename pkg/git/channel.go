@@ -0,0 +1,107 @@
+package git
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+)
+
+// Channel maps branches to a prerelease identifier, e.g. "release/*" to
+// "rc" for 1.2.0-rc.1.
+type Channel struct {
+	// Branch is a path.Match pattern matched against the branch name
+	Branch string
+
+	// Identifier of the prereleases on the channel
+	Identifier string
+}
+
+// ParseChannel parses a channel from "branch=identifier".
+func ParseChannel(s string) (Channel, error) {
+	branch, id, ok := strings.Cut(s, "=")
+	if !ok || branch == "" || id == "" {
+		return Channel{}, fmt.Errorf("invalid channel %q, expected branch=identifier", s)
+	}
+	if _, err := path.Match(branch, ""); err != nil {
+		return Channel{}, fmt.Errorf("invalid branch pattern %q: %w", branch, err)
+	}
+	if !containsOnlyAlphas(id) {
+		return Channel{}, fmt.Errorf("invalid prerelease identifier %q", id)
+	}
+	return Channel{Branch: branch, Identifier: id}, nil
+}
+
+// Channel returns the active prerelease channel. The second return value is
+// false if there is none, in which case only final versions are considered.
+func (g *Git) Channel() (Channel, bool) {
+	return g.channel, g.channel.Identifier != ""
+}
+
+// resolveChannel selects the first channel matching the branch. Without
+// configured channels IncludeRC selects an "rc" channel using the compact
+// "rc1" style.
+func (g *Git) resolveChannel() error {
+	if len(g.cfg.Channels) == 0 {
+		if g.cfg.IncludeRC {
+			g.channel = Channel{Identifier: "rc"}
+			g.compactPre = true
+		}
+		return nil
+	}
+
+	branch, err := g.branch()
+	if err != nil {
+		return err
+	}
+	for _, c := range g.cfg.Channels {
+		ok, err := path.Match(c.Branch, branch)
+		if err != nil {
+			return fmt.Errorf("match branch pattern %q: %w", c.Branch, err)
+		}
+		if ok {
+			g.channel = c
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// branch returns the configured branch, the Ref if it is a branch or the
+// branch checked out at HEAD. It returns an empty string for a detached
+// HEAD.
+func (g *Git) branch() (string, error) {
+	if g.cfg.Branch != "" {
+		return g.cfg.Branch, nil
+	}
+	if g.cfg.Ref != "" {
+		if _, err := g.repo.Reference(plumbing.NewBranchReferenceName(g.cfg.Ref), false); err == nil {
+			return g.cfg.Ref, nil
+		}
+		return "", nil
+	}
+
+	head, err := g.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("get head: %w", err)
+	}
+	if !head.Name().IsBranch() {
+		return "", nil
+	}
+	return head.Name().Short(), nil
+}
+
+// onChannel checks if the prerelease of v belongs to the active channel,
+// either as "rc.1" or "rc1".
+func (g *Git) onChannel(v semver.Version) bool {
+	return g.channel.Identifier != "" && v.HasPrerelease(g.channel.Identifier)
+}
+
+func containsOnlyAlphas(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	}) == -1
+}
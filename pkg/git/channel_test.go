@@ -0,0 +1,133 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChannel(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel string
+		want    Channel
+		wantErr bool
+	}{
+		{
+			name:    "branch",
+			channel: "main=beta",
+			want:    Channel{Branch: "main", Identifier: "beta"},
+		},
+		{
+			name:    "pattern",
+			channel: "release/*=rc",
+			want:    Channel{Branch: "release/*", Identifier: "rc"},
+		},
+		{
+			name:    "missing identifier",
+			channel: "main=",
+			wantErr: true,
+		},
+		{
+			name:    "missing separator",
+			channel: "main",
+			wantErr: true,
+		},
+		{
+			name:    "invalid identifier",
+			channel: "main=beta.1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid pattern",
+			channel: "release/[=rc",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseChannel(tt.channel)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChannels(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "first", tag: "v1.0.0"},
+		testCommit{msg: "second", tag: "v1.1.0-beta.1"},
+		testCommit{msg: "third", tag: "v1.1.0-rc1"},
+		testCommit{msg: "fourth"},
+	)
+
+	channels := []Channel{
+		{Branch: "master", Identifier: "beta"},
+		{Branch: "release/*", Identifier: "rc"},
+		{Branch: "next", Identifier: "alpha"},
+	}
+
+	tests := []struct {
+		name    string
+		branch  string
+		channel string
+		minor   bool
+		highest semver.Version
+		expect  semver.Version
+	}{
+		{
+			name:    "checked out branch",
+			channel: "beta",
+			highest: semver.MustParse("v1.1.0-beta.1"),
+			expect:  semver.MustParse("v1.1.0-beta.2"),
+		},
+		{
+			name:    "pattern",
+			branch:  "release/1.1",
+			channel: "rc",
+			highest: semver.MustParse("v1.1.0-rc1"),
+			expect:  semver.MustParse("v1.1.0-rc2"),
+		},
+		{
+			name:    "new channel",
+			branch:  "next",
+			channel: "alpha",
+			minor:   true,
+			highest: semver.MustParse("v1.0.0"),
+			expect:  semver.MustParse("v1.1.0-alpha.1"),
+		},
+		{
+			name:    "no channel",
+			branch:  "feature/foo",
+			highest: semver.MustParse("v1.0.0"),
+			expect:  semver.MustParse("v1.0.1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Open(path, Config{
+				Prefix:    "v",
+				Channels:  channels,
+				Branch:    tt.branch,
+				IncludeRC: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.highest, g.Highest())
+
+			ch, ok := g.Channel()
+			require.Equal(t, tt.channel != "", ok)
+			require.Equal(t, tt.channel, ch.Identifier)
+
+			got, err := g.Increment(false, tt.minor, !tt.minor, false, ok)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, got)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Commit is a commit in the history since the highest version.
+type Commit struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body,omitempty"`
+
+	// PullRequest is the number of the first pull request referenced in the
+	// style of the hosting service, e.g. "(#123)" or "!123", or 0 if there is
+	// none. A GitHub style reference at the end of the subject is used if
+	// the hosting service is not known.
+	PullRequest int `json:"pull_request,omitempty"`
+
+	// PullRequestURL links PullRequest on the hosting service
+	PullRequestURL string `json:"pull_request_url,omitempty"`
+
+	// Issues are the issues referenced in the message, see Config.Issues
+	Issues []Issue `json:"issues,omitempty"`
+}
+
+// Commits returns the commits between the highest version and HEAD,
+// newest first.
+func (g *Git) Commits() ([]Commit, error) {
+	return g.CommitsBetween("", "")
+}
+
+// CommitsBetween returns the commits reachable from to but not from from,
+// newest first, see HistoryBetween.
+func (g *Git) CommitsBetween(from, to string) ([]Commit, error) {
+	commits, _, err := g.commitsBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := g.hosting()
+	out := make([]Commit, 0, len(commits))
+	for _, c := range commits {
+		subject, body, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+		commit := Commit{
+			Hash:    c.Hash.String(),
+			Author:  c.Author.Name,
+			Email:   c.Author.Email,
+			Date:    c.Author.When,
+			Subject: strings.TrimSpace(subject),
+			Body:    strings.TrimSpace(body),
+		}
+		if repo != nil {
+			if n, ok := repo.PullRequest(c.Message); ok {
+				commit.PullRequest = n
+				if commit.PullRequestURL, err = repo.PullRequestURL(n); err != nil {
+					return nil, err
+				}
+			}
+		} else if m := prNumFromCommit.FindStringSubmatch(commit.Subject); m != nil {
+			commit.PullRequest, _ = strconv.Atoi(m[1])
+		}
+		if commit.Issues, err = g.issues(c, repo); err != nil {
+			return nil, err
+		}
+		out = append(out, commit)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,67 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/softsense/git-semver/pkg/hosting"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommits(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "first", tag: "v1.0.0"},
+		testCommit{msg: "Add feature (#12)\n\nLonger description\nof the feature.\n"},
+		testCommit{msg: "Fix (#13) in the middle"},
+	)
+	g, err := Open(path, Config{Prefix: "v"})
+	require.NoError(t, err)
+
+	commits, err := g.Commits()
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+
+	hashes := commitHashes(t, g)
+	require.Equal(t, hashes[0], commits[0].Hash[:7])
+	require.Equal(t, "Fix (#13) in the middle", commits[0].Subject)
+	require.Equal(t, "", commits[0].Body)
+	require.Equal(t, 0, commits[0].PullRequest)
+
+	require.True(t, time.Date(2021, 5, 3, 12, 2, 0, 0, time.UTC).Equal(commits[1].Date))
+	require.Equal(t, Commit{
+		Hash:        commits[1].Hash,
+		Author:      "test",
+		Email:       "test@example.com",
+		Date:        commits[1].Date,
+		Subject:     "Add feature (#12)",
+		Body:        "Longer description\nof the feature.",
+		PullRequest: 12,
+	}, commits[1])
+}
+
+func TestReferencedIssues(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "first", tag: "v1.0.0"},
+		testCommit{msg: "PROJ-1 add feature"},
+		testCommit{msg: "PROJ-2 fix feature\n\nFollow-up to PROJ-1, see also PROJ-2"},
+		testCommit{msg: "Update dependencies"},
+	)
+	jira, err := hosting.NewIssueTracker(`\b[A-Z][A-Z0-9]+-[0-9]+\b`, "https://jira.example.com/browse/{{.ID}}")
+	require.NoError(t, err)
+	g, err := Open(path, Config{Prefix: "v", Issues: []hosting.IssueTracker{jira}})
+	require.NoError(t, err)
+
+	commits, err := g.Commits()
+	require.NoError(t, err)
+	require.Len(t, commits, 3)
+	require.Empty(t, commits[0].Issues)
+	require.Equal(t, []Issue{
+		{ID: "PROJ-2", URL: "https://jira.example.com/browse/PROJ-2"},
+		{ID: "PROJ-1", URL: "https://jira.example.com/browse/PROJ-1"},
+	}, commits[1].Issues)
+
+	require.Equal(t, []Issue{
+		{ID: "PROJ-2", URL: "https://jira.example.com/browse/PROJ-2", Commits: []string{commits[1].Hash}},
+		{ID: "PROJ-1", URL: "https://jira.example.com/browse/PROJ-1", Commits: []string{commits[1].Hash, commits[2].Hash}},
+	}, ReferencedIssues(commits))
+}
@@ -0,0 +1,165 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	conventionalHeader = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^()\r\n]*)\))?(!)?: (.+)$`)
+	breakingFooter     = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: (.+)$`)
+)
+
+// Bump is the part of a version that a set of changes calls for.
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// ParseBump parses the name of a bump as returned by Bump.String.
+func ParseBump(s string) (Bump, error) {
+	for _, b := range []Bump{BumpNone, BumpPatch, BumpMinor, BumpMajor} {
+		if s == b.String() {
+			return b, nil
+		}
+	}
+	return BumpNone, fmt.Errorf("invalid bump %q, expected none, patch, minor or major", s)
+}
+
+// ConventionalCommit is a commit message parsed according to
+// https://www.conventionalcommits.org/en/v1.0.0/
+type ConventionalCommit struct {
+	Hash        string
+	Type        string
+	Scope       string
+	Description string
+	Body        string
+
+	// Breaking is set by a "!" after the type/scope or a BREAKING CHANGE footer
+	Breaking bool
+
+	// BreakingNote is the text of the BREAKING CHANGE footer, if any
+	BreakingNote string
+}
+
+// Bump returns the version bump the commit calls for.
+func (c ConventionalCommit) Bump() Bump {
+	switch {
+	case c.Breaking:
+		return BumpMajor
+	case c.Type == "feat":
+		return BumpMinor
+	case c.Type == "fix":
+		return BumpPatch
+	default:
+		return BumpNone
+	}
+}
+
+// ParseConventionalCommit parses a commit message. The second return value
+// is false if the message header does not follow the Conventional Commits
+// format.
+func ParseConventionalCommit(msg string) (ConventionalCommit, bool) {
+	header, body, _ := strings.Cut(strings.TrimSpace(msg), "\n")
+	m := conventionalHeader.FindStringSubmatch(strings.TrimSpace(header))
+	if m == nil {
+		return ConventionalCommit{}, false
+	}
+
+	c := ConventionalCommit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       m[2],
+		Breaking:    m[3] == "!",
+		Description: strings.TrimSpace(m[4]),
+		Body:        strings.TrimSpace(body),
+	}
+	if f := breakingFooter.FindStringSubmatch(c.Body); f != nil {
+		c.Breaking = true
+		c.BreakingNote = strings.TrimSpace(f[1])
+	}
+
+	return c, true
+}
+
+// BumpDecision is the result of inferring a version bump from commit messages.
+type BumpDecision struct {
+	Bump Bump
+
+	// Reason is a human readable explanation of the decision
+	Reason string
+
+	// Commits holds the conventional commits since the highest version,
+	// newest first. Commits that are not conventional are left out.
+	Commits []ConventionalCommit
+}
+
+// InferBump parses the commits between the highest version and HEAD as
+// Conventional Commits and decides which part of the version to bump.
+// BumpNone is returned if no commit calls for a release.
+func (g *Git) InferBump() (BumpDecision, error) {
+	commits, _, err := g.commitsSinceHighest()
+	if err != nil {
+		return BumpDecision{}, err
+	}
+
+	var (
+		d     BumpDecision
+		cause ConventionalCommit
+	)
+	for _, c := range commits {
+		cc, ok := ParseConventionalCommit(c.Message)
+		if !ok {
+			continue
+		}
+		cc.Hash = c.Hash.String()
+		d.Commits = append(d.Commits, cc)
+
+		// commits are newest first, keep the oldest commit causing the bump
+		if b := g.bumpFor(cc); b >= d.Bump && b != BumpNone {
+			d.Bump = b
+			cause = cc
+		}
+	}
+
+	switch {
+	case d.Bump == BumpNone:
+		d.Reason = fmt.Sprintf("no commits calling for a release since %s", g.Format(g.highest))
+	case cause.Breaking:
+		note := cause.BreakingNote
+		if note == "" {
+			note = cause.Description
+		}
+		d.Reason = fmt.Sprintf("breaking change in %s: %s", cause.Hash[:7], note)
+	default:
+		d.Reason = fmt.Sprintf("%s in %s: %s", cause.Type, cause.Hash[:7], cause.Description)
+	}
+
+	return d, nil
+}
+
+// bumpFor returns the bump for c, applying the configured bump rules to
+// commits that are not breaking changes.
+func (g *Git) bumpFor(c ConventionalCommit) Bump {
+	if b, ok := g.cfg.BumpRules[c.Type]; ok && !c.Breaking {
+		return b
+	}
+	return c.Bump()
+}
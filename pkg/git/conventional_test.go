@@ -0,0 +1,170 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		name   string
+		msg    string
+		want   ConventionalCommit
+		wantOK bool
+	}{
+		{
+			name:   "feature",
+			msg:    "feat: add thing",
+			want:   ConventionalCommit{Type: "feat", Description: "add thing"},
+			wantOK: true,
+		},
+		{
+			name:   "fix with scope and body",
+			msg:    "fix(parser): handle empty input\n\nIt used to panic.\n",
+			want:   ConventionalCommit{Type: "fix", Scope: "parser", Description: "handle empty input", Body: "It used to panic."},
+			wantOK: true,
+		},
+		{
+			name:   "breaking with bang",
+			msg:    "refactor(api)!: drop v1 endpoints",
+			want:   ConventionalCommit{Type: "refactor", Scope: "api", Description: "drop v1 endpoints", Breaking: true},
+			wantOK: true,
+		},
+		{
+			name: "breaking change footer",
+			msg:  "feat: new config format\n\nBREAKING CHANGE: the old format is no longer read",
+			want: ConventionalCommit{
+				Type:         "feat",
+				Description:  "new config format",
+				Body:         "BREAKING CHANGE: the old format is no longer read",
+				Breaking:     true,
+				BreakingNote: "the old format is no longer read",
+			},
+			wantOK: true,
+		},
+		{
+			name: "not conventional",
+			msg:  "Merge pull request #12 from foo/bar",
+		},
+		{
+			name: "missing space after colon",
+			msg:  "feat:add thing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseConventionalCommit(tt.msg)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseBump(t *testing.T) {
+	for _, b := range []Bump{BumpNone, BumpPatch, BumpMinor, BumpMajor} {
+		got, err := ParseBump(b.String())
+		require.NoError(t, err)
+		require.Equal(t, b, got)
+	}
+
+	_, err := ParseBump("huge")
+	require.Error(t, err)
+}
+
+func TestInferBump(t *testing.T) {
+	tests := []struct {
+		name    string
+		commits []testCommit
+		want    Bump
+		rules   map[string]Bump
+		reason  string
+		parsed  int
+	}{
+		{
+			name: "fix",
+			commits: []testCommit{
+				{msg: "feat: first", tag: "v1.0.0"},
+				{msg: "fix: broken thing"},
+				{msg: "docs: readme"},
+			},
+			want:   BumpPatch,
+			reason: "fix in",
+			parsed: 2,
+		},
+		{
+			name: "feature wins over fix",
+			commits: []testCommit{
+				{msg: "feat: first", tag: "v1.0.0"},
+				{msg: "fix: broken thing"},
+				{msg: "feat: new thing"},
+				{msg: "chore: cleanup"},
+			},
+			want:   BumpMinor,
+			reason: "feat in",
+			parsed: 3,
+		},
+		{
+			name: "breaking",
+			commits: []testCommit{
+				{msg: "feat: first", tag: "v1.0.0"},
+				{msg: "feat: new thing\n\nBREAKING CHANGE: removes old thing"},
+				{msg: "fix: broken thing"},
+			},
+			want:   BumpMajor,
+			reason: "breaking change in",
+			parsed: 2,
+		},
+		{
+			name: "bump rules",
+			commits: []testCommit{
+				{msg: "feat: first", tag: "v1.0.0"},
+				{msg: "perf: faster"},
+				{msg: "feat: not released on its own"},
+				{msg: "fix!: breaking is always major"},
+			},
+			rules:  map[string]Bump{"perf": BumpMinor, "feat": BumpNone, "fix": BumpNone},
+			want:   BumpMajor,
+			reason: "breaking change in",
+			parsed: 3,
+		},
+		{
+			name: "bump rule causing the bump",
+			commits: []testCommit{
+				{msg: "feat: first", tag: "v1.0.0"},
+				{msg: "perf: faster"},
+				{msg: "fix: bug"},
+			},
+			rules:  map[string]Bump{"perf": BumpMinor},
+			want:   BumpMinor,
+			reason: "perf in",
+			parsed: 2,
+		},
+		{
+			name: "commits before the tag are ignored",
+			commits: []testCommit{
+				{msg: "feat!: first"},
+				{msg: "feat: second", tag: "v1.0.0"},
+				{msg: "ci: pipeline"},
+				{msg: "some other change"},
+			},
+			want:   BumpNone,
+			reason: "no commits calling for a release since v1.0.0",
+			parsed: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Open(initRepo(t, tt.commits...), Config{Prefix: "v", BumpRules: tt.rules})
+			require.NoError(t, err)
+
+			d, err := g.InferBump()
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d.Bump)
+			require.Contains(t, d.Reason, tt.reason)
+			require.Len(t, d.Commits, tt.parsed)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package git
+
+import (
+	"errors"
+
+	"github.com/softsense/git-semver/pkg/semver"
+)
+
+// ErrNoVersion is returned by Current if there is no version tag.
+var ErrNoVersion = errors.New("no version tag found")
+
+// Current returns the highest version without bumping it. The second return
+// value is true if HEAD is the commit tagged with it. ErrNoVersion is
+// returned if no tag matches.
+func (g *Git) Current() (semver.Version, bool, error) {
+	if g.highestRef == nil {
+		return semver.Version{}, false, ErrNoVersion
+	}
+
+	tagged, err := g.tagCommitHash(g.highestRef)
+	if err != nil {
+		return semver.Version{}, false, err
+	}
+	head, err := g.Head()
+	if err != nil {
+		return semver.Version{}, false, err
+	}
+
+	return g.highest, head == tagged, nil
+}
@@ -0,0 +1,103 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrent(t *testing.T) {
+	tests := []struct {
+		name    string
+		commits []testCommit
+		expect  string
+		atHead  bool
+		wantErr error
+	}{
+		{
+			name: "head is tagged",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.0.0"},
+				{msg: "second", tag: "v1.1.0"},
+			},
+			expect: "v1.1.0",
+			atHead: true,
+		},
+		{
+			name: "commits since tag",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.0.0"},
+				{msg: "second"},
+			},
+			expect: "v1.0.0",
+		},
+		{
+			name: "zero version tag",
+			commits: []testCommit{
+				{msg: "first", tag: "v0.0.0"},
+			},
+			expect: "v0.0.0",
+			atHead: true,
+		},
+		{
+			name: "no tags",
+			commits: []testCommit{
+				{msg: "first"},
+			},
+			wantErr: ErrNoVersion,
+		},
+		{
+			name: "other prefix",
+			commits: []testCommit{
+				{msg: "first", tag: "api1.0.0"},
+			},
+			wantErr: ErrNoVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Open(initRepo(t, tt.commits...), Config{Prefix: "v"})
+			require.NoError(t, err)
+
+			got, atHead, err := g.Current()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, semver.MustParse(tt.expect), got)
+			require.Equal(t, tt.atHead, atHead)
+		})
+	}
+}
+
+func TestNonCanonicalTags(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "first", tag: "v1.2"},
+		testCommit{msg: "second", tag: "v1.3-rc1"},
+		testCommit{msg: "third"},
+	)
+	g, err := Open(path, Config{Prefix: "v", Scheme: PEP440{}})
+	require.NoError(t, err)
+
+	got, atHead, err := g.Current()
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.0", g.Format(got))
+	require.False(t, atHead)
+
+	commits, found, err := g.commitsSinceHighest()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Len(t, commits, 2)
+
+	n, err := g.Promote(true)
+	require.NoError(t, err)
+	require.Equal(t, "v1.3.0", g.Format(n))
+
+	pseudo, err := g.PseudoVersion()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(pseudo.String(), "v1.3.0-rc.1.0."), pseudo.String())
+}
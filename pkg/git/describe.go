@@ -0,0 +1,54 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/softsense/git-semver/pkg/semver"
+)
+
+// Describe returns a version for HEAD like git describe does, counting the
+// commits since the highest version. With 17 commits on top of 1.2.3 it
+// returns 1.2.4-dev.17+g1a2b3c4, on top of 1.2.4-rc1 it returns
+// 1.2.4-rc1.dev.17+g1a2b3c4, so successive builds are ordered by precedence.
+// The build metadata gets a "dirty" identifier if the worktree has
+// uncommitted changes. If HEAD is the highest version and the worktree is
+// clean the highest version is returned.
+func (g *Git) Describe() (semver.Version, error) {
+	commits, _, err := g.commitsSinceHighest()
+	if err != nil {
+		return semver.Version{}, err
+	}
+	dirty, err := g.isDirty()
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	v, err := semver.Parse(g.highest.String())
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("create version: %w", err)
+	}
+	if len(commits) == 0 && !dirty {
+		return v, nil
+	}
+
+	if len(v.Pre) == 0 {
+		if err := v.IncrementPatch(); err != nil {
+			return semver.Version{}, fmt.Errorf("increment: %w", err)
+		}
+	}
+	v.Pre = append(v.Pre,
+		semver.PRVersion{VersionStr: "dev"},
+		semver.PRVersion{VersionNum: uint64(len(commits)), IsNum: true},
+	)
+
+	head, err := g.Head()
+	if err != nil {
+		return semver.Version{}, err
+	}
+	v.Build = []string{"g" + head.String()[:7]}
+	if dirty {
+		v.Build = append(v.Build, dirtyBuild)
+	}
+
+	return v, nil
+}
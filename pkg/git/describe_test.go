@@ -0,0 +1,90 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name      string
+		commits   []testCommit
+		includeRC bool
+		dirty     bool
+		expect    string
+	}{
+		{
+			name: "on tag",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.2.3"},
+			},
+			expect: "v1.2.3",
+		},
+		{
+			name: "on tag, dirty",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.2.3"},
+			},
+			dirty:  true,
+			expect: "v1.2.4-dev.0+gHASH.dirty",
+		},
+		{
+			name: "commits since tag",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.2.3"},
+				{msg: "second"},
+				{msg: "third"},
+			},
+			expect: "v1.2.4-dev.2+gHASH",
+		},
+		{
+			name: "commits since rc",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.2.3"},
+				{msg: "second", tag: "v1.3.0-rc1"},
+				{msg: "third"},
+			},
+			includeRC: true,
+			expect:    "v1.3.0-rc1.dev.1+gHASH",
+		},
+		{
+			name: "no tags",
+			commits: []testCommit{
+				{msg: "first"},
+				{msg: "second"},
+			},
+			expect: "v0.0.1-dev.2+gHASH",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := initRepo(t, tt.commits...)
+			if tt.dirty {
+				require.NoError(t, os.WriteFile(filepath.Join(path, "untracked.txt"), nil, 0o644))
+			}
+			g, err := Open(path, Config{Prefix: "v", IncludeRC: tt.includeRC})
+			require.NoError(t, err)
+
+			got, err := g.Describe()
+			require.NoError(t, err)
+
+			head, err := g.Head()
+			require.NoError(t, err)
+			require.Equal(t, semver.MustParse(strings.ReplaceAll(tt.expect, "HASH", head.String()[:7])), got)
+		})
+	}
+}
+
+func TestDescribeOrder(t *testing.T) {
+	a := semver.MustParse("v1.2.4-dev.9+gaaaaaaa")
+	b := semver.MustParse("v1.2.4-dev.10+gbbbbbbb")
+	require.True(t, a.LT(b))
+	require.True(t, b.LT(semver.MustParse("v1.2.4")))
+	require.True(t, b.GT(semver.MustParse("v1.2.3")))
+}
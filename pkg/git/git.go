@@ -1,22 +1,21 @@
 package git
 
 import (
-	"errors"
 	"fmt"
+	"path"
 	"regexp"
-	"strconv"
 	"strings"
 
+	"github.com/softsense/git-semver/pkg/hosting"
 	"github.com/softsense/git-semver/pkg/semver"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
 )
 
-var (
-	prNumFromCommit = regexp.MustCompile(`\(#([0-9]+)\)($|\n)`)
-	gitHostAndPath  = regexp.MustCompile(`git@(.+):(.*)\.git`)
-)
+// prNumFromCommit matches GitHub style pull request references, used when
+// the hosting service of the repository is not known.
+var prNumFromCommit = regexp.MustCompile(`\(#([0-9]+)\)($|\n)`)
 
 type Config struct {
 	// Prefix to add to version strings
@@ -25,14 +24,83 @@ type Config struct {
 	// Only look at tags below version
 	Below *semver.Version
 
-	// Include ReleaseCandidate Version
+	// Only look at tags within range
+	Within *semver.Range
+
+	// Include ReleaseCandidate Version. Ignored if Channels are set.
 	IncludeRC bool
+
+	// Channels map branches to prerelease identifiers. The first channel
+	// matching Branch is active: prereleases on it are considered and
+	// incremented. Other prereleases are ignored.
+	Channels []Channel
+
+	// Branch selects the channel, defaults to the branch checked out at
+	// HEAD or Ref
+	Branch string
+
+	// Ref to compute versions from, defaults to HEAD. Accepts anything
+	// git rev-parse does, e.g. branch names, tags and hashes.
+	Ref string
+
+	// AllTags considers every tag in the repository. By default only tags
+	// on commits reachable from Ref are considered.
+	AllTags bool
+
+	// BumpRules override the bump for conventional commit types, e.g.
+	// "perf" to BumpMinor. Breaking changes always bump major.
+	BumpRules map[string]Bump
+
+	// Paths limits history and bump inference to commits touching these
+	// paths, relative to the repository root. Combined with a path style
+	// Prefix such as "api/v" this versions a component of a monorepo.
+	Paths []string
+
+	// Scheme parses, formats and bumps versions, defaults to SemVer
+	Scheme Scheme
+
+	// FirstParent follows only the first parent of merge commits in history,
+	// like git log --first-parent, so commits of merged branches are left
+	// out and only the merges themselves are included
+	FirstParent bool
+
+	// NoMerges leaves merge commits out of history
+	NoMerges bool
+
+	// Hosts map self-hosted services to their provider, for links to pull
+	// requests. Public services like github.com are recognized without.
+	Hosts []hosting.Host
+
+	// Issues link issue references in commit messages, like Jira keys or
+	// "Fixes #12", and collect them per release
+	Issues []hosting.IssueTracker
 }
 
 type Git struct {
 	highest semver.Version
 	repo    *git.Repository
 	cfg     Config
+
+	// channel is the active prerelease channel, compactPre is set when new
+	// prereleases on it use the "rc1" rather than the "rc.1" style
+	channel    Channel
+	compactPre bool
+
+	// release and prerelease are the highest final and prerelease versions,
+	// regardless of the channel
+	release    semver.Version
+	prerelease semver.Version
+
+	// highestRef, releaseRef and prereleaseRef are the tags of highest,
+	// release and prerelease, nil if there is none. Tags are looked up by ref
+	// since their names need not be the formatted version, like "v1.2" for
+	// 1.2.0 with PEP 440.
+	highestRef    *plumbing.Reference
+	releaseRef    *plumbing.Reference
+	prereleaseRef *plumbing.Reference
+
+	// tags holds every version tag with the prefix, before filtering
+	tags []tagRef
 }
 
 func Open(path string, cfg Config) (*Git, error) {
@@ -41,17 +109,38 @@ func Open(path string, cfg Config) (*Git, error) {
 		return nil, fmt.Errorf("open git repo %s: %w", path, err)
 	}
 
-	var highest semver.Version
-	highest.Prefix = cfg.Prefix
+	g := &Git{
+		repo: r,
+		cfg:  cfg,
+	}
+	g.highest.Prefix = cfg.Prefix
+	g.release.Prefix = cfg.Prefix
+	g.prerelease.Prefix = cfg.Prefix
+
+	if err := g.resolveChannel(); err != nil {
+		return nil, err
+	}
+
+	var reachable map[plumbing.Hash]bool
+	if !cfg.AllTags {
+		head, err := g.Head()
+		if err != nil {
+			return nil, err
+		}
+		reachable, err = g.ancestors(head)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	all := make(map[string]semver.Version)
+	var versions []tagRef
 
 	tagrefs, err := r.Tags()
 	if err != nil {
 		return nil, fmt.Errorf("list tags: %w", err)
 	}
 	err = tagrefs.ForEach(func(t *plumbing.Reference) error {
-		n, err := parseTagRef(string(t.Name()))
+		n, err := g.parseTagRef(string(t.Name()))
 		if err != nil {
 			return nil
 		}
@@ -60,38 +149,40 @@ func Open(path string, cfg Config) (*Git, error) {
 		if n.Prefix != cfg.Prefix {
 			return nil
 		}
+		g.tags = append(g.tags, tagRef{version: n, ref: t})
 
-		v := format(n)
-		allN, ok := all[v]
-		if ok {
-			if n.GT(allN) {
-				all[v] = n
+		if reachable != nil {
+			c, err := g.tagCommitHash(t)
+			if err != nil {
+				return err
 			}
-		} else {
-			all[v] = n
-		}
-
-		if len(n.Pre) > 0 {
-			if !cfg.IncludeRC || !strings.HasPrefix(n.Pre[0].String(), "rc") {
+			if !reachable[c] {
 				return nil
 			}
 		}
+
 		if cfg.Below != nil && n.GTE(*cfg.Below) {
 			return nil
 		}
-		if n.GT(highest) {
-			highest = n
+		if cfg.Within != nil && !cfg.Within.Contains(n) {
+			return nil
 		}
+		versions = append(versions, tagRef{version: n, ref: t})
 		return nil
 	})
 	if err != nil {
 		return nil, fmt.Errorf("loop over tags: %w", err)
 	}
 
-	g := &Git{
-		highest: highest,
-		repo:    r,
-		cfg:     cfg,
+	if t, ok := maxTag(versions, func(v semver.Version) bool { return len(v.Pre) == 0 }); ok {
+		g.release, g.releaseRef = t.version, t.ref
+	}
+	if t, ok := maxTag(versions, func(v semver.Version) bool { return len(v.Pre) > 0 }); ok {
+		g.prerelease, g.prereleaseRef = t.version, t.ref
+	}
+	// prereleases count only on the active channel
+	if t, ok := maxTag(versions, func(v semver.Version) bool { return len(v.Pre) == 0 || g.onChannel(v) }); ok {
+		g.highest, g.highestRef = t.version, t.ref
 	}
 
 	return g, nil
@@ -104,59 +195,48 @@ func (g *Git) Increment(major, minor, patch, dev bool, rc bool) (semver.Version,
 	}
 
 	if rc {
-		found := false
-		for i, pre := range newVersion.Pre {
-			if strings.HasPrefix(pre.VersionStr, "rc") {
-				rcNumStr := strings.Replace(pre.VersionStr, "rc", "", 1)
-				rcNum, err := strconv.Atoi(rcNumStr)
-				if err != nil {
-					return semver.Version{}, fmt.Errorf("parse rc number: %w", err)
-				}
-				rcNum++
-				newVersion.Pre[i], err = semver.NewPRVersion(fmt.Sprintf("rc%d", rcNum))
-				if err != nil {
-					return semver.Version{}, fmt.Errorf("create rc version: %w", err)
-				}
-				found = true
-				patch = false
-				minor = false
-				major = false
-				break
-			}
+		id, compact := g.channel.Identifier, g.compactPre
+		if id == "" {
+			id, compact = "rc", true
 		}
-		if !found {
-			rcVer, err := semver.NewPRVersion("rc1")
-			if err != nil {
-				return semver.Version{}, fmt.Errorf("build rc version: %w", err)
+		switch {
+		case newVersion.HasPrerelease(id):
+			if err := newVersion.IncrementPrerelease(id); err != nil {
+				return semver.Version{}, fmt.Errorf("increment %s version: %w", id, err)
 			}
-			newVersion.Pre = []semver.PRVersion{rcVer}
+			patch = false
+			minor = false
+			major = false
+		case compact:
+			newVersion, err = newVersion.WithPrerelease(id + "1")
+		default:
+			newVersion, err = newVersion.WithPrerelease(id, "1")
 		}
-	}
-
-	if patch {
-		if err := newVersion.IncrementPatch(); err != nil {
-			return semver.Version{}, fmt.Errorf("increment: %w", err)
+		if err != nil {
+			return semver.Version{}, fmt.Errorf("build %s version: %w", id, err)
 		}
 	}
 
-	if minor {
-		if err := newVersion.IncrementMinor(); err != nil {
-			return semver.Version{}, fmt.Errorf("increment: %w", err)
-		}
+	bump := BumpNone
+	switch {
+	case major:
+		bump = BumpMajor
+	case minor:
+		bump = BumpMinor
+	case patch:
+		bump = BumpPatch
 	}
-
-	if major {
-		if err := newVersion.IncrementMajor(); err != nil {
-			return semver.Version{}, fmt.Errorf("increment: %w", err)
-		}
+	newVersion, err = g.scheme().Next(newVersion, bump)
+	if err != nil {
+		return semver.Version{}, err
 	}
 
 	if dev {
-		head, err := g.repo.Head()
+		head, err := g.Head()
 		if err != nil {
-			return semver.Version{}, fmt.Errorf("get repo head: %w", err)
+			return semver.Version{}, err
 		}
-		snapshot, err := semver.NewPRVersion(fmt.Sprintf("snapshot-%s", head.Hash().String()[:7]))
+		snapshot, err := semver.NewPRVersion(fmt.Sprintf("snapshot-%s", head.String()[:7]))
 		if err != nil {
 			return semver.Version{}, fmt.Errorf("build snapshot version: %w", err)
 		}
@@ -166,88 +246,302 @@ func (g *Git) Increment(major, minor, patch, dev bool, rc bool) (semver.Version,
 	return newVersion, nil
 }
 
+// History returns the commits since the highest version, see HistoryBetween.
 func (g *Git) History(prefix string) (string, error) {
-	head, err := g.repo.Head()
+	return g.HistoryBetween(prefix, "", "")
+}
+
+// HistoryBetween returns the commits reachable from to but not from from,
+// newest first, one line per commit with each line prefixed with prefix.
+// from and to are resolved by Resolve. An empty from is the highest
+// version, or the entire history if its tag is not found, an empty to is
+// HEAD or Ref.
+func (g *Git) HistoryBetween(prefix, from, to string) (string, error) {
+	commits, found, err := g.commitsBetween(from, to)
 	if err != nil {
-		return "", fmt.Errorf("get head: %w", err)
+		return "", err
+	}
+	if !found {
+		fmt.Printf("Tag %s not found, including the entire history\n", g.Format(g.highest))
+	}
+
+	out := make([]string, 0, len(commits))
+	for _, c := range commits {
+		msg := fmt.Sprintf("%s* %s %s\n", prefix, c.Hash.String()[:7], strings.ReplaceAll(strings.TrimSuffix(c.Message, "\n"), "\n", "\n  "))
+		if prefix != "" {
+			msg = strings.ReplaceAll(msg, "\n", fmt.Sprintf("\n%s", prefix))
+		}
+		msg += "\n"
+		msg = g.linkReferences(msg)
+
+		out = append(out, msg)
 	}
 
-	var prevHash *plumbing.Hash
-	prevRef, err := g.repo.Tag(g.highest.String())
+	return strings.Join(out, ""), nil
+}
+
+// commitsSinceHighest returns the commits from HEAD back to the commit of
+// the highest version, newest first. The second return value is false if the
+// tag of the highest version was not found, in which case the entire
+// history is returned.
+func (g *Git) commitsSinceHighest() ([]*object.Commit, bool, error) {
+	return g.commitsBetween("", "")
+}
+
+// commitsBetween returns the commits reachable from to but not from from,
+// newest first, see HistoryBetween. Commits on branches merged after from
+// are included even if they are older than from. The second return value is
+// false if from is empty and the tag of the highest version was not found.
+func (g *Git) commitsBetween(from, to string) ([]*object.Commit, bool, error) {
+	var (
+		toHash plumbing.Hash
+		err    error
+	)
+	if to == "" {
+		toHash, err = g.Head()
+	} else {
+		toHash, err = g.Resolve(to)
+	}
 	if err != nil {
-		fmt.Printf("Tag %s not found, including the entire history\n", g.highest.String())
+		return nil, false, err
+	}
+
+	var fromHash *plumbing.Hash
+	found := true
+	if from == "" {
+		if g.highestRef != nil {
+			h, err := g.tagCommitHash(g.highestRef)
+			if err != nil {
+				return nil, false, err
+			}
+			fromHash = &h
+		}
+		found = fromHash != nil
 	} else {
-		cIter, err := g.repo.Log(&git.LogOptions{From: prevRef.Hash()})
+		h, err := g.Resolve(from)
 		if err != nil {
-			return "", fmt.Errorf("get log from %s: %w", prevRef.Hash(), err)
-		}
-		c, err := cIter.Next()
-		if err == nil {
-			prevHash = &c.Hash
+			return nil, false, err
 		}
+		fromHash = &h
 	}
 
-	cIter, err := g.repo.Log(&git.LogOptions{From: head.Hash()})
+	out, err := g.commitsRange(fromHash, toHash)
 	if err != nil {
-		return "", fmt.Errorf("get log from %s: %w", head.Hash(), err)
+		return nil, false, err
 	}
-	out := make([]string, 0)
-	_ = cIter.ForEach(func(c *object.Commit) error {
-		if prevHash != nil && c.Hash.String() == prevHash.String() {
-			return errors.New("EOF")
+	return out, found, nil
+}
+
+// commitsRange returns the commits reachable from to and not from from,
+// newest first, or the entire history up to to if from is nil.
+func (g *Git) commitsRange(from *plumbing.Hash, to plumbing.Hash) ([]*object.Commit, error) {
+	exclude := make(map[plumbing.Hash]bool)
+	if from != nil {
+		var err error
+		exclude, err = g.ancestors(*from)
+		if err != nil {
+			return nil, err
 		}
-		msg := fmt.Sprintf("%s* %s %s\n", prefix, c.Hash.String()[:7], strings.ReplaceAll(strings.TrimSuffix(c.Message, "\n"), "\n", "\n  "))
-		if prefix != "" {
-			msg = strings.ReplaceAll(msg, "\n", fmt.Sprintf("\n%s", prefix))
+	}
+
+	out := make([]*object.Commit, 0)
+	err := g.walk(to, exclude, func(c *object.Commit) error {
+		if g.cfg.NoMerges && c.NumParents() > 1 {
+			return nil
 		}
-		msg += "\n"
-		msg = insertPullRequestURL(msg, g)
+		ok, err := g.touchesPaths(c)
+		if err != nil {
+			return err
+		}
+		if ok {
+			out = append(out, c)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		out = append(out, msg)
+	return out, nil
+}
 
+// walk calls fn for the commits reachable from h but not in exclude, newest
+// first by committer time, or only for the first parents with FirstParent.
+// exclude must hold the ancestors of each commit in it.
+func (g *Git) walk(h plumbing.Hash, exclude map[plumbing.Hash]bool, fn func(c *object.Commit) error) error {
+	if g.cfg.FirstParent {
+		c, err := g.repo.CommitObject(h)
+		if err != nil {
+			return fmt.Errorf("get commit %s: %w", h, err)
+		}
+		for !exclude[c.Hash] {
+			if err := fn(c); err != nil {
+				return err
+			}
+			if c.NumParents() == 0 {
+				return nil
+			}
+			parent, err := c.Parent(0)
+			if err != nil {
+				return fmt.Errorf("get parent of %s: %w", c.Hash, err)
+			}
+			c = parent
+		}
 		return nil
+	}
+
+	cIter, err := g.repo.Log(&git.LogOptions{From: h, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return fmt.Errorf("get log from %s: %w", h, err)
+	}
+	return cIter.ForEach(func(c *object.Commit) error {
+		if exclude[c.Hash] {
+			return nil
+		}
+		return fn(c)
 	})
+}
 
-	return strings.Join(out, ""), nil
+// Resolve returns the commit of rev, which is a tag, the version of a tag
+// like "1.2.0" for the tag "v1.2.0", or anything git rev-parse accepts,
+// e.g. a branch or a hash.
+func (g *Git) Resolve(rev string) (plumbing.Hash, error) {
+	if ref, err := g.repo.Tag(rev); err == nil {
+		return g.tagCommitHash(ref)
+	}
+	if v, err := g.Parse(rev); err == nil {
+		if t, ok := g.versionTag(v); ok {
+			return g.tagCommitHash(t.ref)
+		}
+	}
+
+	h, err := g.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("resolve %s: %w", rev, err)
+	}
+	return *h, nil
+}
+
+// touchesPaths checks if commit c changes any of the configured paths
+// compared to its first parent. Every commit matches if no paths are
+// configured.
+func (g *Git) touchesPaths(c *object.Commit) (bool, error) {
+	if len(g.cfg.Paths) == 0 {
+		return true, nil
+	}
+
+	tree, err := c.Tree()
+	if err != nil {
+		return false, fmt.Errorf("get tree of %s: %w", c.Hash, err)
+	}
+	var parentTree *object.Tree
+	if c.NumParents() > 0 {
+		parent, err := c.Parent(0)
+		if err != nil {
+			return false, fmt.Errorf("get parent of %s: %w", c.Hash, err)
+		}
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return false, fmt.Errorf("get tree of %s: %w", parent.Hash, err)
+		}
+	}
+
+	for _, p := range g.cfg.Paths {
+		p = strings.Trim(path.Clean(p), "/")
+		if p == "." || p == "" {
+			return true, nil
+		}
+		if pathHash(tree, p) != pathHash(parentTree, p) {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
 
 func (g *Git) Highest() semver.Version {
 	return g.highest
 }
 
-// insertPullRequestURL replaces GitHub PR references in commit messages
-// with the full URL to the PR.
-func insertPullRequestURL(msg string, git *Git) string {
-	remotes, err := git.repo.Remotes()
-	if err != nil || len(remotes) < 1 {
-		return msg
+// Head returns the hash of the commit versions are computed from, Ref or
+// HEAD.
+func (g *Git) Head() (plumbing.Hash, error) {
+	if g.cfg.Ref == "" {
+		head, err := g.repo.Head()
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("get head: %w", err)
+		}
+		return head.Hash(), nil
 	}
-	if len(remotes[0].Config().URLs) < 1 {
-		return msg
+
+	h, err := g.repo.ResolveRevision(plumbing.Revision(g.cfg.Ref))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("resolve %s: %w", g.cfg.Ref, err)
 	}
-	url := remotes[0].Config().URLs[0]
-	if !(strings.HasPrefix(url, "git@github.com") || strings.HasPrefix(url, "https://github.com")) {
-		return msg
+	return *h, nil
+}
+
+// ancestors returns the set of commits reachable from h, including h.
+func (g *Git) ancestors(h plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	cIter, err := g.repo.Log(&git.LogOptions{From: h})
+	if err != nil {
+		return nil, fmt.Errorf("get log from %s: %w", h, err)
 	}
 
-	// Convert non-HTTPS URL to HTTPS
-	url = gitHostAndPath.ReplaceAllString(url, "https://$1/$2")
+	out := make(map[plumbing.Hash]bool)
+	err = cIter.ForEach(func(c *object.Commit) error {
+		out[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk log from %s: %w", h, err)
+	}
 
-	link := fmt.Sprintf("[(#$1)](%s/pull/$1)$2", url)
-	msg = prNumFromCommit.ReplaceAllString(msg, link)
+	return out, nil
+}
+
+// hosting returns the hosting service of the origin remote, or of the first
+// remote if there is no origin, or nil if it is not recognized.
+func (g *Git) hosting() *hosting.Repo {
+	remotes, err := g.repo.Remotes()
+	if err != nil || len(remotes) < 1 {
+		return nil
+	}
+	remote := remotes[0].Config()
+	for _, r := range remotes {
+		if r.Config().Name == git.DefaultRemoteName {
+			remote = r.Config()
+		}
+	}
+	if len(remote.URLs) < 1 {
+		return nil
+	}
 
-	return msg
+	repo, err := hosting.ParseRemote(remote.URLs[0], g.cfg.Hosts)
+	if err != nil {
+		return nil
+	}
+	return repo
 }
 
-func parseTagRef(t string) (semver.Version, error) {
+// pathHash returns the hash of the blob or tree at p, or the zero hash if
+// there is nothing at p.
+func pathHash(t *object.Tree, p string) plumbing.Hash {
+	if t == nil {
+		return plumbing.ZeroHash
+	}
+	e, err := t.FindEntry(p)
+	if err != nil {
+		return plumbing.ZeroHash
+	}
+	return e.Hash
+}
+
+func (g *Git) parseTagRef(t string) (semver.Version, error) {
 	s := strings.Replace(t, "refs/tags/", "", 1)
-	v, err := semver.Parse(s)
+	v, err := g.scheme().Parse(s)
 	if err != nil {
 		return semver.Version{}, err
 	}
 	return v, nil
 }
-
-func format(v semver.Version) string {
-	return fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
-}
@@ -8,13 +8,17 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mholt/archives"
+	"github.com/softsense/git-semver/pkg/hosting"
 	"github.com/softsense/git-semver/pkg/semver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/go-git.v4/storage/memory"
 )
 
@@ -29,9 +33,24 @@ func TestMain(m *testing.M) {
 	// v0.0.2
 	// v0.3.0
 	//
+	// repo-merge.tar.gz has branches merged before and after v1.1.0:
+	// * fix: fix after merge
+	// *   Merge branch 'feature'
+	// |\
+	// | * feat: extend feature
+	// | * feat: add feature
+	// * | fix: fix bug (v1.1.0)
+	// * |   Merge branch 'docs'
+	// |\ \
+	// | |/
+	// |/|
+	// | * docs: document usage
+	// |/
+	// * chore: initial commit (v1.0.0)
+	//
 	ctx := context.Background()
 
-	for _, name := range []string{"repo.tar.gz", "repo-rc.tar.gz"} {
+	for _, name := range []string{"repo.tar.gz", "repo-rc.tar.gz", "repo-merge.tar.gz"} {
 		if err := untarGz(ctx, filepath.Join("testdata", name), "testdata"); err != nil {
 			panic(err)
 		}
@@ -41,6 +60,7 @@ func TestMain(m *testing.M) {
 
 	os.RemoveAll("testdata/repo")
 	os.RemoveAll("testdata/repo-rc")
+	os.RemoveAll("testdata/repo-merge")
 
 	os.Exit(exitCode)
 }
@@ -107,6 +127,187 @@ func TestBelow(t *testing.T) {
 	}
 }
 
+func TestWithin(t *testing.T) {
+	tests := []struct {
+		name   string
+		within semver.Range
+		expect semver.Version
+	}{
+		{
+			name:   "all",
+			within: semver.MustParseRange("*"),
+			expect: semver.MustParse("v0.3.0"),
+		},
+		{
+			name:   "patch line",
+			within: semver.MustParseRange("0.0.x"),
+			expect: semver.MustParse("v0.0.2"),
+		},
+		{
+			name:   "bounded",
+			within: semver.MustParseRange(">=0.0.1 <0.0.2"),
+			expect: semver.MustParse("v0.0.1"),
+		},
+		{
+			name:   "none",
+			within: semver.MustParseRange(">=1.0.0"),
+			expect: semver.MustParse("v0.0.0"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := Open("testdata/repo-rc", Config{
+				Prefix: "v",
+				Within: &test.within,
+			})
+			require.NoError(t, err)
+			require.Equal(t, test.expect, g.Highest())
+		})
+	}
+}
+
+func TestReachable(t *testing.T) {
+	// master: first (v1.0.0) - second (v2.0.0) - third
+	// maint:  first (v1.0.0) - fix (v1.0.1) - another fix
+	path := initRepo(t,
+		testCommit{msg: "first", tag: "v1.0.0"},
+		testCommit{msg: "second", tag: "v2.0.0"},
+		testCommit{msg: "third"},
+	)
+	r, err := git.PlainOpen(path)
+	require.NoError(t, err)
+	first, err := r.ResolveRevision("v1.0.0")
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, w.Checkout(&git.CheckoutOptions{
+		Hash:   *first,
+		Branch: plumbing.NewBranchReferenceName("maint"),
+		Create: true,
+	}))
+	for _, c := range []testCommit{{msg: "fix", tag: "v1.0.1"}, {msg: "another fix"}} {
+		h, err := w.Commit(c.msg, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		if c.tag != "" {
+			_, err := r.CreateTag(c.tag, h, nil)
+			require.NoError(t, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		ref     string
+		allTags bool
+		expect  semver.Version
+	}{
+		{
+			name:   "maint branch head",
+			expect: semver.MustParse("v1.0.1"),
+		},
+		{
+			name:    "maint branch head, all tags",
+			allTags: true,
+			expect:  semver.MustParse("v2.0.0"),
+		},
+		{
+			name:   "master ref",
+			ref:    "master",
+			expect: semver.MustParse("v2.0.0"),
+		},
+		{
+			name:   "tag ref",
+			ref:    "v1.0.0",
+			expect: semver.MustParse("v1.0.0"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := Open(path, Config{
+				Prefix:  "v",
+				Ref:     test.ref,
+				AllTags: test.allTags,
+			})
+			require.NoError(t, err)
+			require.Equal(t, test.expect, g.Highest())
+		})
+	}
+
+	_, err = Open(path, Config{Prefix: "v", Ref: "does-not-exist"})
+	require.Error(t, err)
+}
+
+func TestComponents(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "feat: api", file: "api/main.go", tag: "api/v1.0.0"},
+		testCommit{msg: "feat: worker", file: "worker/main.go", tag: "worker/v0.4.0"},
+		testCommit{msg: "fix(api): bug", file: "api/main.go"},
+		testCommit{msg: "feat(worker): thing", file: "worker/thing.go"},
+		testCommit{msg: "docs: readme", file: "README.md"},
+	)
+
+	tests := []struct {
+		name    string
+		prefix  string
+		paths   []string
+		highest semver.Version
+		bump    Bump
+		commits int
+		next    semver.Version
+	}{
+		{
+			name:    "api",
+			prefix:  "api/v",
+			paths:   []string{"api"},
+			highest: semver.MustParse("api/v1.0.0"),
+			bump:    BumpPatch,
+			commits: 1,
+			next:    semver.MustParse("api/v1.0.1"),
+		},
+		{
+			name:    "worker",
+			prefix:  "worker/v",
+			paths:   []string{"./worker/"},
+			highest: semver.MustParse("worker/v0.4.0"),
+			bump:    BumpMinor,
+			commits: 1,
+			next:    semver.MustParse("worker/v0.4.1"),
+		},
+		{
+			name:    "worker and docs",
+			prefix:  "worker/v",
+			paths:   []string{"worker", "README.md"},
+			highest: semver.MustParse("worker/v0.4.0"),
+			bump:    BumpMinor,
+			commits: 2,
+			next:    semver.MustParse("worker/v0.4.1"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := Open(path, Config{
+				Prefix: test.prefix,
+				Paths:  test.paths,
+			})
+			require.NoError(t, err)
+			require.Equal(t, test.highest, g.Highest())
+
+			d, err := g.InferBump()
+			require.NoError(t, err)
+			require.Equal(t, test.bump, d.Bump)
+			require.Len(t, d.Commits, test.commits)
+
+			next, err := g.Increment(false, false, true, false, false)
+			require.NoError(t, err)
+			require.Equal(t, test.next, next)
+		})
+	}
+}
+
 func TestRC(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -272,10 +473,184 @@ func TestHistory(t *testing.T) {
 	}
 }
 
-func TestInsertPullRequestURL(t *testing.T) {
+func TestHistoryBetween(t *testing.T) {
+	// main:    first (v1.0.0) - fix (v1.1.0) - merge - after
+	// feature:        \- feature ----------/
+	path := initRepo(t, testCommit{msg: "first", tag: "v1.0.0"})
+	r, err := git.PlainOpen(path)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	main, err := r.Head()
+	require.NoError(t, err)
+
+	when := time.Date(2021, 5, 3, 13, 0, 0, 0, time.UTC)
+	commit := func(msg string, parents ...plumbing.Hash) plumbing.Hash {
+		when = when.Add(time.Minute)
+		require.NoError(t, os.WriteFile(filepath.Join(path, msg+".txt"), []byte(msg), 0o644))
+		_, err := w.Add(msg + ".txt")
+		require.NoError(t, err)
+		h, err := w.Commit(msg, &git.CommitOptions{
+			Author:  &object.Signature{Name: "test", Email: "test@example.com", When: when},
+			Parents: parents,
+		})
+		require.NoError(t, err)
+		return h
+	}
+
+	require.NoError(t, w.Checkout(&git.CheckoutOptions{Branch: "refs/heads/feature", Create: true}))
+	feature := commit("feature")
+	require.NoError(t, w.Checkout(&git.CheckoutOptions{Branch: main.Name()}))
+	fix := commit("fix")
+	_, err = r.CreateTag("v1.1.0", fix, nil)
+	require.NoError(t, err)
+	commit("merge", fix, feature)
+	commit("after")
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		expect  []string
+		wantErr string
+	}{
+		{
+			name:   "since highest",
+			expect: []string{"after", "merge", "feature"},
+		},
+		{
+			name:   "tags",
+			from:   "v1.0.0",
+			to:     "v1.1.0",
+			expect: []string{"fix"},
+		},
+		{
+			name:   "versions",
+			from:   "1.0.0",
+			to:     "1.1.0",
+			expect: []string{"fix"},
+		},
+		{
+			name:   "branch",
+			from:   "v1.0.0",
+			to:     "feature",
+			expect: []string{"feature"},
+		},
+		{
+			name:   "hash",
+			from:   fix.String(),
+			to:     "HEAD~1",
+			expect: []string{"merge", "feature"},
+		},
+		{
+			name:    "unknown",
+			from:    "v0.9.0",
+			wantErr: "resolve v0.9.0: reference not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Open(path, Config{Prefix: "v"})
+			require.NoError(t, err)
+
+			commits, err := g.CommitsBetween(tt.from, tt.to)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			subjects := make([]string, 0, len(commits))
+			for _, c := range commits {
+				subjects = append(subjects, c.Subject)
+			}
+			require.ElementsMatch(t, tt.expect, subjects)
+
+			history, err := g.HistoryBetween("", tt.from, tt.to)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.expect), strings.Count(history, "* "))
+		})
+	}
+}
+
+func TestHistoryMerges(t *testing.T) {
+	tests := []struct {
+		name        string
+		firstParent bool
+		noMerges    bool
+		from        string
+		expect      []string
+		bump        Bump
+	}{
+		{
+			name:   "all",
+			expect: []string{"fix: fix after merge", "Merge branch 'feature'", "feat: extend feature", "feat: add feature"},
+			bump:   BumpMinor,
+		},
+		{
+			name:        "first parent",
+			firstParent: true,
+			expect:      []string{"fix: fix after merge", "Merge branch 'feature'"},
+			bump:        BumpPatch,
+		},
+		{
+			name:     "no merges",
+			noMerges: true,
+			expect:   []string{"fix: fix after merge", "feat: extend feature", "feat: add feature"},
+			bump:     BumpMinor,
+		},
+		{
+			name:        "first parent, no merges",
+			firstParent: true,
+			noMerges:    true,
+			expect:      []string{"fix: fix after merge"},
+			bump:        BumpPatch,
+		},
+		{
+			name:   "merged before from",
+			from:   "v1.0.0",
+			expect: []string{"fix: fix after merge", "Merge branch 'feature'", "feat: extend feature", "fix: fix bug", "Merge branch 'docs'", "feat: add feature", "docs: document usage"},
+		},
+		{
+			name:        "merged before from, first parent",
+			firstParent: true,
+			from:        "v1.0.0",
+			expect:      []string{"fix: fix after merge", "Merge branch 'feature'", "fix: fix bug", "Merge branch 'docs'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Open("testdata/repo-merge", Config{
+				Prefix:      "v",
+				FirstParent: tt.firstParent,
+				NoMerges:    tt.noMerges,
+			})
+			require.NoError(t, err)
+
+			commits, err := g.CommitsBetween(tt.from, "")
+			require.NoError(t, err)
+			subjects := make([]string, 0, len(commits))
+			for _, c := range commits {
+				subjects = append(subjects, c.Subject)
+			}
+			require.Equal(t, tt.expect, subjects)
+
+			if tt.from == "" {
+				d, err := g.InferBump()
+				require.NoError(t, err)
+				require.Equal(t, tt.bump, d.Bump)
+			}
+		})
+	}
+}
+
+func TestLinkPullRequests(t *testing.T) {
 	tests := []struct {
 		name      string
 		remoteUrl string
+		hosts     []hosting.Host
 		msg       string
 		want      string
 	}{
@@ -315,6 +690,19 @@ func TestInsertPullRequestURL(t *testing.T) {
 			msg:       "15f53d3 Some commit message (#1)",
 			want:      "15f53d3 Some commit message (#1)",
 		},
+		{
+			name:      "GitLab merge request ref - SSH remote with port",
+			remoteUrl: "ssh://git@gitlab.com:2222/foo/bar.git",
+			msg:       "15f53d3 Some commit message (!1)",
+			want:      "15f53d3 Some commit message ([!1](https://gitlab.com/foo/bar/-/merge_requests/1))",
+		},
+		{
+			name:      "Self-hosted Gitea",
+			remoteUrl: "git@example.com:foo/bar.git",
+			hosts:     []hosting.Host{{Name: "example.com", Provider: hosting.Gitea}},
+			msg:       "15f53d3 Some commit message (#1)",
+			want:      "15f53d3 Some commit message [(#1)](https://example.com/foo/bar/pulls/1)",
+		},
 	}
 
 	for _, test := range tests {
@@ -324,6 +712,7 @@ func TestInsertPullRequestURL(t *testing.T) {
 				repo: &git.Repository{
 					Storer: memory.NewStorage(),
 				},
+				cfg: Config{Hosts: tc.hosts},
 			}
 			_, err := g.repo.CreateRemote(&config.RemoteConfig{
 				Name:  "origin",
@@ -332,12 +721,58 @@ func TestInsertPullRequestURL(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			msg := insertPullRequestURL(tc.msg, g)
+			msg := g.linkReferences(tc.msg)
 			assert.Equal(t, tc.want, msg)
 		})
 	}
 }
 
+// testCommit describes a commit created by initRepo. If tag is set the
+// commit is tagged with a lightweight tag. The commit writes file, or a new
+// file in the repository root if file is empty.
+type testCommit struct {
+	msg  string
+	tag  string
+	file string
+}
+
+// initRepo creates a repository in a temporary directory with one commit
+// per testCommit, each adding a file, and returns its path.
+func initRepo(t *testing.T, commits ...testCommit) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	r, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+
+	when := time.Date(2021, 5, 3, 12, 0, 0, 0, time.UTC)
+	for i, c := range commits {
+		name := c.file
+		if name == "" {
+			name = fmt.Sprintf("file%d.txt", i)
+		}
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(c.msg), 0o644))
+		_, err := w.Add(name)
+		require.NoError(t, err)
+
+		when = when.Add(time.Minute)
+		h, err := w.Commit(c.msg, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: when},
+		})
+		require.NoError(t, err)
+
+		if c.tag != "" {
+			_, err := r.CreateTag(c.tag, h, nil)
+			require.NoError(t, err)
+		}
+	}
+
+	return dir
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
@@ -0,0 +1,60 @@
+package git
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/softsense/git-semver/pkg/gomod"
+	"github.com/softsense/git-semver/pkg/semver"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// VerifyGoMod checks that the module path declared in the go.mod file at
+// name matches the major version of v, see gomod.CheckMajor. An empty name
+// is the go.mod of the component, in the directory of a path style prefix
+// like "api/v", or of the repository root. It returns the module path.
+func (g *Git) VerifyGoMod(v semver.Version, name string) (string, error) {
+	if name == "" {
+		name = "go.mod"
+		if i := strings.LastIndex(g.cfg.Prefix, "/"); i != -1 {
+			name = path.Join(g.cfg.Prefix[:i], "go.mod")
+		}
+	}
+
+	data, err := g.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	module, err := gomod.ModulePath(data)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return module, gomod.CheckMajor(module, v.Major)
+}
+
+// ReadFile returns the content of the file at name, relative to the
+// repository root, in HEAD or Ref.
+func (g *Git) ReadFile(name string) ([]byte, error) {
+	head, err := g.Head()
+	if err != nil {
+		return nil, err
+	}
+	c, err := g.repo.CommitObject(head)
+	if err != nil {
+		return nil, fmt.Errorf("get commit %s: %w", head, err)
+	}
+
+	f, err := c.File(path.Clean(name))
+	if err == object.ErrFileNotFound {
+		return nil, fmt.Errorf("%s not found in %s", name, head.String()[:7])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", name, err)
+	}
+	s, err := f.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+	return []byte(s), nil
+}
@@ -0,0 +1,80 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyGoMod(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "module example.com/repo/v2\n", file: "go.mod", tag: "v2.0.0"},
+		testCommit{msg: "module example.com/repo/api\n", file: "api/go.mod", tag: "api/v1.0.0"},
+	)
+
+	tests := []struct {
+		name    string
+		prefix  string
+		file    string
+		version string
+		module  string
+		wantErr string
+	}{
+		{
+			name:    "root",
+			prefix:  "v",
+			version: "v2.1.0",
+			module:  "example.com/repo/v2",
+		},
+		{
+			name:    "root, major bump",
+			prefix:  "v",
+			version: "v3.0.0",
+			module:  "example.com/repo/v2",
+			wantErr: "go.mod declares module example.com/repo/v2, which is for v2, but the version is v3: change the module directive to example.com/repo/v3 and update the import paths",
+		},
+		{
+			name:    "component",
+			prefix:  "api/v",
+			version: "api/v1.0.1",
+			module:  "example.com/repo/api",
+		},
+		{
+			name:    "component, major bump",
+			prefix:  "api/v",
+			version: "api/v2.0.0",
+			module:  "example.com/repo/api",
+			wantErr: "go.mod declares module example.com/repo/api, which is for v0 or v1, but the version is v2: change the module directive to example.com/repo/api/v2 and update the import paths",
+		},
+		{
+			name:    "explicit file",
+			prefix:  "v",
+			file:    "api/go.mod",
+			version: "v1.0.0",
+			module:  "example.com/repo/api",
+		},
+		{
+			name:    "missing file",
+			prefix:  "v",
+			file:    "web/go.mod",
+			version: "v1.0.0",
+			wantErr: "web/go.mod not found in",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Open(path, Config{Prefix: tt.prefix})
+			require.NoError(t, err)
+
+			module, err := g.VerifyGoMod(semver.MustParse(tt.version), tt.file)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.module, module)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package git
+
+import (
+	"github.com/softsense/git-semver/pkg/hosting"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// Issue is an issue referenced in commit messages, see Config.Issues.
+type Issue struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+
+	// Commits are the hashes of the commits referencing the issue, newest
+	// first. Only set by ReferencedIssues.
+	Commits []string `json:"commits,omitempty"`
+}
+
+// ReferencedIssues collects the issues referenced by commits, in the order
+// they are first referenced, each with the commits referencing it.
+func ReferencedIssues(commits []Commit) []Issue {
+	var out []Issue
+	index := make(map[string]int)
+	for _, c := range commits {
+		for _, issue := range c.Issues {
+			i, ok := index[issue.URL]
+			if !ok {
+				i = len(out)
+				index[issue.URL] = i
+				out = append(out, Issue{ID: issue.ID, URL: issue.URL})
+			}
+			if n := len(out[i].Commits); n == 0 || out[i].Commits[n-1] != c.Hash {
+				out[i].Commits = append(out[i].Commits, c.Hash)
+			}
+		}
+	}
+	return out
+}
+
+// issues returns the issues referenced in the message of c, once each.
+func (g *Git) issues(c *object.Commit, repo *hosting.Repo) ([]Issue, error) {
+	var out []Issue
+	seen := make(map[string]bool)
+	for _, t := range g.cfg.Issues {
+		refs, err := t.References(c.Message, repo)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range refs {
+			if !seen[r.URL] {
+				seen[r.URL] = true
+				out = append(out, Issue{ID: r.ID, URL: r.URL})
+			}
+		}
+	}
+	return out, nil
+}
+
+// linkReferences replaces pull request references in commit messages with
+// links to the pull requests on the hosting service, and issue references
+// with links to the issues.
+func (g *Git) linkReferences(msg string) string {
+	repo := g.hosting()
+
+	var refs []hosting.Reference
+	if repo != nil {
+		prs, err := repo.PullRequests(msg)
+		if err != nil {
+			return msg
+		}
+		refs = append(refs, prs...)
+	}
+	for _, t := range g.cfg.Issues {
+		issues, err := t.References(msg, repo)
+		if err != nil {
+			return msg
+		}
+		refs = append(refs, issues...)
+	}
+	return hosting.Link(msg, refs)
+}
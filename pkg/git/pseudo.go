@@ -0,0 +1,62 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/softsense/git-semver/pkg/semver"
+)
+
+// pseudoTime is the layout of the commit time in a Go pseudo-version.
+const pseudoTime = "20060102150405"
+
+// PseudoVersion returns the Go module pseudo-version of HEAD as computed by
+// the go command, from the highest version tag, the commit time in UTC and
+// the first 12 characters of the commit hash:
+//
+//	v0.0.0-20261017120000-abcdef123456          without a version tag
+//	v1.2.4-0.20261017120000-abcdef123456        on top of v1.2.3
+//	v1.2.4-rc.1.0.20261017120000-abcdef123456   on top of v1.2.4-rc.1
+//
+// If HEAD is tagged the tagged version is returned. Pseudo-versions always
+// start with v, without the path of a prefix like "api/v".
+func (g *Git) PseudoVersion() (semver.Version, error) {
+	head, err := g.Head()
+	if err != nil {
+		return semver.Version{}, err
+	}
+	c, err := g.repo.CommitObject(head)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("get commit %s: %w", head, err)
+	}
+
+	base, ref := g.release, g.releaseRef
+	if g.prerelease.GT(base) {
+		base, ref = g.prerelease, g.prereleaseRef
+	}
+	v := semver.Version{Prefix: "v", Major: base.Major}
+
+	if ref != nil {
+		tagged, err := g.tagCommitHash(ref)
+		if err != nil {
+			return semver.Version{}, err
+		}
+		v = base
+		v.Prefix, v.Build = "v", nil
+		if tagged == head {
+			return v, nil
+		}
+
+		if len(v.Pre) == 0 {
+			v.Patch++
+		}
+		v.Pre = append(append([]semver.PRVersion(nil), v.Pre...), semver.PRVersion{VersionNum: 0, IsNum: true})
+	}
+
+	rev, err := semver.NewPRVersion(c.Committer.When.UTC().Format(pseudoTime) + "-" + head.String()[:12])
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("build pseudo-version: %w", err)
+	}
+	v.Pre = append(v.Pre, rev)
+
+	return v, nil
+}
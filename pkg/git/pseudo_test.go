@@ -0,0 +1,80 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPseudoVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		commits []testCommit
+		expect  string
+	}{
+		{
+			name: "no tag",
+			commits: []testCommit{
+				{msg: "first"},
+				{msg: "second"},
+			},
+			prefix: "v",
+			expect: "v0.0.0-20210503120200-HASH",
+		},
+		{
+			name: "release tag",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.2.3"},
+				{msg: "second"},
+			},
+			prefix: "v",
+			expect: "v1.2.4-0.20210503120200-HASH",
+		},
+		{
+			name: "prerelease tag",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.2.3"},
+				{msg: "second", tag: "v1.2.4-rc.1"},
+				{msg: "third"},
+			},
+			prefix: "v",
+			expect: "v1.2.4-rc.1.0.20210503120300-HASH",
+		},
+		{
+			name: "tagged head",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.2.3"},
+			},
+			prefix: "v",
+			expect: "v1.2.3",
+		},
+		{
+			name: "path prefix",
+			commits: []testCommit{
+				{msg: "first", tag: "api/v2.0.0"},
+				{msg: "second"},
+			},
+			prefix: "api/v",
+			expect: "v2.0.1-0.20210503120200-HASH",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Open(initRepo(t, tt.commits...), Config{Prefix: tt.prefix})
+			require.NoError(t, err)
+
+			got, err := g.PseudoVersion()
+			require.NoError(t, err)
+
+			head, err := g.Head()
+			require.NoError(t, err)
+			expect := strings.ReplaceAll(tt.expect, "HASH", head.String()[:12])
+			require.Equal(t, semver.MustParse(expect), got)
+			require.Equal(t, expect, got.String())
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/softsense/git-semver/pkg/semver"
+)
+
+// Promote returns the final version of the highest prerelease above the
+// highest release, 1.4.0 for 1.4.0-rc3 on top of 1.3.2. HEAD must be the
+// commit tagged with the prerelease or, if allowDescendant is set, a commit
+// descending from it, so that the release ships what was tested.
+func (g *Git) Promote(allowDescendant bool) (semver.Version, error) {
+	if len(g.prerelease.Pre) == 0 {
+		return semver.Version{}, errors.New("no prerelease to promote")
+	}
+	v := g.prerelease
+	v.Finalize()
+	if !v.GT(g.release) {
+		return semver.Version{}, fmt.Errorf("no prerelease to promote above %s", g.Format(g.release))
+	}
+
+	tagged, err := g.tagCommitHash(g.prereleaseRef)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	head, err := g.Head()
+	if err != nil {
+		return semver.Version{}, err
+	}
+	if head == tagged {
+		return v, nil
+	}
+	if !allowDescendant {
+		return semver.Version{}, fmt.Errorf("HEAD %s is not the commit tagged %s", head.String()[:7], g.Format(g.prerelease))
+	}
+
+	ancestors, err := g.ancestors(head)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	if !ancestors[tagged] {
+		return semver.Version{}, fmt.Errorf("HEAD %s does not descend from %s", head.String()[:7], g.Format(g.prerelease))
+	}
+
+	return v, nil
+}
+
+// Prerelease returns the highest prerelease version, regardless of the
+// channel.
+func (g *Git) Prerelease() semver.Version {
+	return g.prerelease
+}
@@ -0,0 +1,110 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromote(t *testing.T) {
+	tests := []struct {
+		name            string
+		commits         []testCommit
+		allowDescendant bool
+		expect          string
+		wantErr         string
+	}{
+		{
+			name: "head is rc",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.3.2"},
+				{msg: "second", tag: "v1.4.0-rc3"},
+			},
+			expect: "v1.4.0",
+		},
+		{
+			name: "dotted rc",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.3.2"},
+				{msg: "second", tag: "v1.4.0-rc.1"},
+				{msg: "third", tag: "v1.4.0-rc.2"},
+			},
+			expect: "v1.4.0",
+		},
+		{
+			name: "head descends from rc",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.4.0-rc3"},
+				{msg: "second"},
+			},
+			wantErr: "is not the commit tagged v1.4.0-rc3",
+		},
+		{
+			name: "head descends from rc, allowed",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.4.0-rc3"},
+				{msg: "second"},
+			},
+			allowDescendant: true,
+			expect:          "v1.4.0",
+		},
+		{
+			name: "no prerelease",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.3.2"},
+			},
+			wantErr: "no prerelease to promote",
+		},
+		{
+			name: "already released",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.4.0-rc3"},
+				{msg: "second", tag: "v1.4.0"},
+			},
+			wantErr: "no prerelease to promote above v1.4.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Open(initRepo(t, tt.commits...), Config{Prefix: "v"})
+			require.NoError(t, err)
+
+			got, err := g.Promote(tt.allowDescendant)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, semver.MustParse(tt.expect), got)
+		})
+	}
+}
+
+func TestPromoteAndTag(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "first", tag: "v1.3.0"},
+		testCommit{msg: "second", tag: "v1.4.0-rc3"},
+	)
+	g, err := Open(path, Config{Prefix: "v"})
+	require.NoError(t, err)
+
+	for _, v := range []string{"v1.4.0-rc4", "v1.3.1", "v1.4.1", "v1.5.0", "v1.5.0-rc1"} {
+		_, err = g.CreateTag(semver.MustParse(v), TagOptions{})
+		require.ErrorContains(t, err, "is already tagged as v1.4.0-rc3", v)
+	}
+
+	n, err := g.Promote(false)
+	require.NoError(t, err)
+	ref, err := g.CreateTag(n, TagOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "refs/tags/v1.4.0", ref.Name().String())
+
+	g, err = Open(path, Config{Prefix: "v"})
+	require.NoError(t, err)
+	require.Equal(t, semver.MustParse("v1.4.0"), g.Highest())
+
+	_, err = g.CreateTag(semver.MustParse("v1.4.1"), TagOptions{})
+	require.ErrorContains(t, err, "is already tagged as v1.4.0")
+}
@@ -0,0 +1,145 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/softsense/git-semver/pkg/calver"
+	"github.com/softsense/git-semver/pkg/semver"
+)
+
+// Scheme is a versioning scheme. Versions of every scheme are represented as
+// semver.Version, so tag discovery, ordering, history and tagging are shared.
+type Scheme interface {
+	// Parse parses a tag name into a version
+	Parse(tag string) (semver.Version, error)
+
+	// Format returns the tag name of a version
+	Format(v semver.Version) string
+
+	// Next returns the version following v for bump, keeping the prerelease
+	Next(v semver.Version, bump Bump) (semver.Version, error)
+}
+
+// ParseScheme parses "semver", "pep440" or "calver:LAYOUT", e.g.
+// "calver:YY.0M.MICRO".
+func ParseScheme(s string) (Scheme, error) {
+	switch s {
+	case "", "semver":
+		return SemVer{}, nil
+	case "pep440":
+		return PEP440{}, nil
+	}
+	layout, ok := strings.CutPrefix(s, "calver:")
+	if !ok {
+		return nil, fmt.Errorf("invalid scheme %q, expected semver, pep440 or calver:LAYOUT", s)
+	}
+	l, err := calver.ParseLayout(layout)
+	if err != nil {
+		return nil, err
+	}
+	return CalVer{Layout: l}, nil
+}
+
+// SemVer is the semantic versioning scheme, the default.
+type SemVer struct{}
+
+func (SemVer) Parse(tag string) (semver.Version, error) {
+	return semver.Parse(tag)
+}
+
+func (SemVer) Format(v semver.Version) string {
+	return v.String()
+}
+
+func (SemVer) Next(v semver.Version, bump Bump) (semver.Version, error) {
+	var err error
+	switch bump {
+	case BumpPatch:
+		err = v.IncrementPatch()
+	case BumpMinor:
+		err = v.IncrementMinor()
+	case BumpMajor:
+		err = v.IncrementMajor()
+	}
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("increment: %w", err)
+	}
+	return v, nil
+}
+
+// PEP440 reads and writes tags as Python PEP 440 versions, e.g. v1.2.3rc1,
+// and bumps them like semantic versions. Any PEP 440 spelling is read, like
+// v1.2 or v1.2.3-RC1, while new tags get the normalized form, so existing
+// tags are found by ref rather than by their formatted name.
+type PEP440 struct{}
+
+func (PEP440) Parse(tag string) (semver.Version, error) {
+	prefix, rest := semver.SplitPrefix(tag)
+	v, err := semver.ParsePEP440(rest)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	v.Prefix = prefix
+	return v, nil
+}
+
+// Format returns the PEP 440 tag of v, or the semantic version if v has no
+// PEP 440 equivalent.
+func (PEP440) Format(v semver.Version) string {
+	s, err := v.PEP440()
+	if err != nil {
+		return v.String()
+	}
+	return v.Prefix + s
+}
+
+func (PEP440) Next(v semver.Version, bump Bump) (semver.Version, error) {
+	return SemVer{}.Next(v, bump)
+}
+
+// CalVer is a calendar versioning scheme. Any bump rolls the date segments
+// of the layout to the current date, the bump type is ignored.
+type CalVer struct {
+	Layout calver.Layout
+
+	// Now returns the current time, defaults to time.Now in UTC
+	Now func() time.Time
+}
+
+func (c CalVer) Parse(tag string) (semver.Version, error) {
+	return c.Layout.Parse(tag)
+}
+
+func (c CalVer) Format(v semver.Version) string {
+	return c.Layout.Format(v)
+}
+
+func (c CalVer) Next(v semver.Version, bump Bump) (semver.Version, error) {
+	if bump == BumpNone {
+		return v, nil
+	}
+	now := time.Now().UTC()
+	if c.Now != nil {
+		now = c.Now()
+	}
+	return c.Layout.Next(v, now)
+}
+
+// Parse parses a version in the configured scheme.
+func (g *Git) Parse(s string) (semver.Version, error) {
+	return g.scheme().Parse(s)
+}
+
+// Format returns the tag name of version v in the configured scheme.
+func (g *Git) Format(v semver.Version) string {
+	return g.scheme().Format(v)
+}
+
+func (g *Git) scheme() Scheme {
+	if g.cfg.Scheme == nil {
+		return SemVer{}
+	}
+	return g.cfg.Scheme
+}
@@ -0,0 +1,171 @@
+package git
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/softsense/git-semver/pkg/calver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheme(t *testing.T) {
+	s, err := ParseScheme("")
+	require.NoError(t, err)
+	require.Equal(t, SemVer{}, s)
+
+	s, err = ParseScheme("calver:YY.0M.MICRO")
+	require.NoError(t, err)
+	require.Equal(t, CalVer{Layout: calver.MustParseLayout("YY.0M.MICRO")}, s)
+
+	_, err = ParseScheme("calver")
+	require.EqualError(t, err, `invalid scheme "calver", expected semver, pep440 or calver:LAYOUT`)
+}
+
+func TestCalVer(t *testing.T) {
+	oct3 := time.Date(2026, 10, 3, 12, 0, 0, 0, time.UTC)
+	nov2 := time.Date(2026, 11, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		commits []testCommit
+		now     time.Time
+		rc      bool
+		highest string
+		expect  string
+	}{
+		{
+			name: "same month",
+			commits: []testCommit{
+				{msg: "first", tag: "v26.09.2"},
+				{msg: "second", tag: "v26.10.0"},
+				{msg: "third"},
+			},
+			now:     oct3,
+			highest: "v26.10.0",
+			expect:  "v26.10.1",
+		},
+		{
+			name: "new month",
+			commits: []testCommit{
+				{msg: "first", tag: "v26.10.4"},
+				{msg: "second"},
+			},
+			now:     nov2,
+			highest: "v26.10.4",
+			expect:  "v26.11.0",
+		},
+		{
+			name: "semver tags are ignored",
+			commits: []testCommit{
+				{msg: "first", tag: "v1.2.3"},
+				{msg: "second"},
+			},
+			now:     oct3,
+			highest: "v0.00.0",
+			expect:  "v26.10.0",
+		},
+		{
+			name: "full year tags are ignored",
+			commits: []testCommit{
+				{msg: "first", tag: "v26.09.0"},
+				{msg: "second", tag: "v2026.10.3"},
+				{msg: "third"},
+			},
+			now:     oct3,
+			highest: "v26.09.0",
+			expect:  "v26.10.0",
+		},
+		{
+			name: "rc",
+			commits: []testCommit{
+				{msg: "first", tag: "v26.09.0"},
+				{msg: "second"},
+			},
+			now:     oct3,
+			rc:      true,
+			highest: "v26.09.0",
+			expect:  "v26.10.0-rc.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := initRepo(t, tt.commits...)
+			scheme := CalVer{Layout: calver.MustParseLayout("YY.0M.MICRO"), Now: func() time.Time { return tt.now }}
+			cfg := Config{Prefix: "v", Scheme: scheme}
+			if tt.rc {
+				cfg.Channels = []Channel{{Branch: "*", Identifier: "rc"}}
+			}
+			g, err := Open(path, cfg)
+			require.NoError(t, err)
+			require.Equal(t, tt.highest, g.Format(g.Highest()))
+
+			n, err := g.Increment(false, false, true, false, tt.rc)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, g.Format(n))
+
+			ref, err := g.CreateTag(n, TagOptions{})
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, ref.Name().Short())
+
+			g, err = Open(path, cfg)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, g.Format(g.Highest()))
+			v, err := g.Parse(tt.expect)
+			require.NoError(t, err)
+			require.Equal(t, v, g.Highest())
+			_, found, err := g.commitsSinceHighest()
+			require.NoError(t, err)
+			require.True(t, found)
+		})
+	}
+
+}
+
+func TestPEP440Scheme(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "first", tag: "v1.2.2"},
+		testCommit{msg: "second", tag: "v1.2.3rc1"},
+		testCommit{msg: "third"},
+	)
+	cfg := Config{Prefix: "v", Scheme: PEP440{}, Channels: []Channel{{Branch: "*", Identifier: "rc"}}}
+	g, err := Open(path, cfg)
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.3rc1", g.Format(g.Highest()))
+
+	n, err := g.Increment(false, false, true, false, true)
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.3rc2", g.Format(n))
+
+	commits, found, err := g.commitsSinceHighest()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Len(t, commits, 1)
+}
+
+func TestPEP440SchemeSpelling(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "first", tag: "v1.2"},
+		testCommit{msg: "second", tag: "v1.2.3-rc1"},
+		testCommit{msg: "third"},
+	)
+	cfg := Config{Prefix: "v", Scheme: PEP440{}, Channels: []Channel{{Branch: "*", Identifier: "rc"}}}
+	g, err := Open(path, cfg)
+	require.NoError(t, err)
+
+	v, atHead, err := g.Current()
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.3rc1", g.Format(v))
+	require.False(t, atHead)
+
+	history, err := g.History("")
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(history, "* "))
+
+	g, err = Open(path, Config{Prefix: "v", Scheme: PEP440{}})
+	require.NoError(t, err)
+	history, err = g.History("")
+	require.NoError(t, err)
+	require.Equal(t, 2, strings.Count(history, "* "))
+}
@@ -0,0 +1,111 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"gopkg.in/src-d/go-git.v4"
+)
+
+// ErrDirty is returned by RequireClean if the worktree has uncommitted
+// changes or untracked files.
+var ErrDirty = errors.New("worktree has uncommitted changes")
+
+// dirtyBuild is the build metadata identifier of versions built from a
+// dirty worktree.
+const dirtyBuild = "dirty"
+
+// Status lists the files of the worktree that differ from HEAD, sorted by
+// path. Ignored files are left out.
+type Status struct {
+	// Modified are tracked files changed in the index or the worktree,
+	// including added, deleted and renamed files
+	Modified []string `json:"modified"`
+
+	// Untracked are files not in the index
+	Untracked []string `json:"untracked"`
+}
+
+// Clean checks if there are no modified or untracked files.
+func (s Status) Clean() bool {
+	return len(s.Modified) == 0 && len(s.Untracked) == 0
+}
+
+// String lists the files like git status --short, one per line.
+func (s Status) String() string {
+	var b strings.Builder
+	for _, f := range s.Modified {
+		fmt.Fprintf(&b, " M %s\n", f)
+	}
+	for _, f := range s.Untracked {
+		fmt.Fprintf(&b, "?? %s\n", f)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Status returns the status of the worktree.
+func (g *Git) Status() (Status, error) {
+	w, err := g.repo.Worktree()
+	if err != nil {
+		return Status{}, fmt.Errorf("get worktree: %w", err)
+	}
+	status, err := w.Status()
+	if err != nil {
+		return Status{}, fmt.Errorf("get worktree status: %w", err)
+	}
+
+	var s Status
+	for path, fs := range status {
+		switch {
+		case fs.Staging == git.Unmodified && fs.Worktree == git.Unmodified:
+		case fs.Worktree == git.Untracked:
+			s.Untracked = append(s.Untracked, path)
+		default:
+			s.Modified = append(s.Modified, path)
+		}
+	}
+	sort.Strings(s.Modified)
+	sort.Strings(s.Untracked)
+	return s, nil
+}
+
+// RequireClean returns an error wrapping ErrDirty and listing the files if
+// the worktree is not clean.
+func (g *Git) RequireClean() error {
+	s, err := g.Status()
+	if err != nil {
+		return err
+	}
+	if !s.Clean() {
+		return fmt.Errorf("%w:\n%s", ErrDirty, s)
+	}
+	return nil
+}
+
+// MarkDirty returns v with a "dirty" build metadata identifier if the
+// worktree is not clean, e.g. 1.2.4+dirty, or v unchanged if it is.
+func (g *Git) MarkDirty(v semver.Version) (semver.Version, error) {
+	dirty, err := g.isDirty()
+	if err != nil || !dirty {
+		return v, err
+	}
+	for _, b := range v.Build {
+		if b == dirtyBuild {
+			return v, nil
+		}
+	}
+	v.Build = append(append([]string(nil), v.Build...), dirtyBuild)
+	return v, nil
+}
+
+// isDirty checks if the worktree has uncommitted changes or untracked files.
+func (g *Git) isDirty() (bool, error) {
+	s, err := g.Status()
+	if err != nil {
+		return false, err
+	}
+	return !s.Clean(), nil
+}
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(t *testing.T, dir string)
+		expect  Status
+		version string
+		wantErr string
+	}{
+		{
+			name:    "clean",
+			change:  func(t *testing.T, dir string) {},
+			version: "v1.2.4",
+		},
+		{
+			name: "modified and untracked",
+			change: func(t *testing.T, dir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "file0.txt"), []byte("changed"), 0o644))
+				require.NoError(t, os.Remove(filepath.Join(dir, "file1.txt")))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "new.txt"), nil, 0o644))
+			},
+			expect: Status{
+				Modified:  []string{"file0.txt", "file1.txt"},
+				Untracked: []string{"new.txt"},
+			},
+			version: "v1.2.4+dirty",
+			wantErr: "worktree has uncommitted changes:\n M file0.txt\n M file1.txt\n?? new.txt",
+		},
+		{
+			name: "ignored",
+			change: func(t *testing.T, dir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.log\n"), 0o644))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "build.log"), nil, 0o644))
+			},
+			expect: Status{
+				Untracked: []string{".gitignore"},
+			},
+			version: "v1.2.4+dirty",
+			wantErr: "worktree has uncommitted changes:\n?? .gitignore",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := initRepo(t,
+				testCommit{msg: "first", tag: "v1.2.3"},
+				testCommit{msg: "second"},
+			)
+			tt.change(t, path)
+
+			g, err := Open(path, Config{Prefix: "v"})
+			require.NoError(t, err)
+
+			s, err := g.Status()
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, s)
+
+			err = g.RequireClean()
+			if tt.wantErr != "" {
+				require.ErrorIs(t, err, ErrDirty)
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			v, err := g.MarkDirty(semver.MustParse("v1.2.4"))
+			require.NoError(t, err)
+			require.Equal(t, semver.MustParse(tt.version), v)
+		})
+	}
+}
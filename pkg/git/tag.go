@@ -0,0 +1,321 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	gitconfig "gopkg.in/src-d/go-git.v4/plumbing/format/config"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// DefaultTagMessage is the message template used for annotated tags when
+// TagOptions.Message is empty.
+const DefaultTagMessage = "Release {{.Version}}"
+
+// TagOptions configures how a version tag is created.
+type TagOptions struct {
+	// Annotated creates an annotated tag instead of a lightweight tag
+	Annotated bool
+
+	// Message is a text/template for the message of an annotated tag.
+	// The fields Version and Previous are available.
+	Message string
+
+	// Tagger of an annotated tag. If nil it is taken from the
+	// GIT_COMMITTER_NAME and GIT_COMMITTER_EMAIL environment variables or
+	// the user section of the repository and global git config.
+	Tagger *object.Signature
+}
+
+// Tag is a version tag in the repository.
+type Tag struct {
+	Name    string         `json:"name"`
+	Version semver.Version `json:"version"`
+
+	// Hash of the tagged commit
+	Hash string    `json:"hash"`
+	Date time.Time `json:"date"`
+
+	// Annotated is false for lightweight tags
+	Annotated bool `json:"annotated"`
+
+	// Reachable is set if the tagged commit is an ancestor of HEAD or Ref
+	Reachable bool `json:"reachable"`
+}
+
+// tagRef is a version tag found by Open.
+type tagRef struct {
+	version semver.Version
+	ref     *plumbing.Reference
+}
+
+// Tags returns every tag that parses as a version with the configured
+// prefix, sorted by precedence. Unlike the highest version it is not
+// limited to reachable tags or by Below and Within.
+func (g *Git) Tags() ([]Tag, error) {
+	head, err := g.Head()
+	if err != nil {
+		return nil, err
+	}
+	reachable, err := g.ancestors(head)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Tag, 0, len(g.tags))
+	for _, t := range g.tags {
+		tag, err := g.newTag(t.version, t.ref)
+		if err != nil {
+			return nil, err
+		}
+		tag.Reachable = reachable[plumbing.NewHash(tag.Hash)]
+		out = append(out, tag)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Version.LT(out[j].Version)
+	})
+
+	return out, nil
+}
+
+// newTag reads the commit and date of the tag ref for version v: the tagger
+// date of an annotated tag or the committer date of the tagged commit.
+func (g *Git) newTag(v semver.Version, ref *plumbing.Reference) (Tag, error) {
+	t := Tag{Name: ref.Name().Short(), Version: v}
+
+	tag, err := g.repo.TagObject(ref.Hash())
+	switch err {
+	case nil:
+		c, err := tag.Commit()
+		if err != nil {
+			return Tag{}, fmt.Errorf("get commit of tag %s: %w", ref.Name().Short(), err)
+		}
+		t.Hash = c.Hash.String()
+		t.Date = tag.Tagger.When
+		t.Annotated = true
+	case plumbing.ErrObjectNotFound:
+		c, err := g.repo.CommitObject(ref.Hash())
+		if err != nil {
+			return Tag{}, fmt.Errorf("get commit of tag %s: %w", ref.Name().Short(), err)
+		}
+		t.Hash = c.Hash.String()
+		t.Date = c.Committer.When
+	default:
+		return Tag{}, fmt.Errorf("get tag %s: %w", ref.Name().Short(), err)
+	}
+
+	return t, nil
+}
+
+// CreateTag tags HEAD, or the configured Ref, with version v. It refuses to tag a commit that
+// already has a version tag with the configured prefix, unless v promotes it, like 1.4.0 on the
+// commit of 1.4.0-rc3.
+func (g *Git) CreateTag(v semver.Version, opts TagOptions) (*plumbing.Reference, error) {
+	head, err := g.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := g.versionTagsAt(head)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range existing {
+		if !promotes(v, e) {
+			return nil, fmt.Errorf("%s is already tagged as %s", head.String()[:7], g.Format(e))
+		}
+	}
+
+	var tagOpts *git.CreateTagOptions
+	if opts.Annotated {
+		msg, err := g.tagMessage(v, opts.Message)
+		if err != nil {
+			return nil, err
+		}
+		tagger := opts.Tagger
+		if tagger == nil {
+			tagger, err = g.identity()
+			if err != nil {
+				return nil, err
+			}
+		}
+		tagOpts = &git.CreateTagOptions{
+			Tagger:  tagger,
+			Message: msg,
+		}
+	}
+
+	ref, err := g.repo.CreateTag(g.Format(v), head, tagOpts)
+	if err != nil {
+		return nil, fmt.Errorf("create tag %s: %w", g.Format(v), err)
+	}
+
+	return ref, nil
+}
+
+// PushTag pushes the tag ref to the named remote. The push fails if the tag
+// already exists on the remote.
+func (g *Git) PushTag(remote string, ref *plumbing.Reference) error {
+	spec := config.RefSpec(fmt.Sprintf("%s:%s", ref.Name(), ref.Name()))
+	err := g.repo.Push(&git.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []config.RefSpec{spec},
+	})
+	if err != nil {
+		return fmt.Errorf("push %s to %s: %w", ref.Name().Short(), remote, err)
+	}
+	return nil
+}
+
+// versionTagsAt returns the versions with the configured prefix that tag
+// the commit h.
+func (g *Git) versionTagsAt(h plumbing.Hash) ([]semver.Version, error) {
+	tagrefs, err := g.repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+
+	var out []semver.Version
+	err = tagrefs.ForEach(func(t *plumbing.Reference) error {
+		n, err := g.parseTagRef(string(t.Name()))
+		if err != nil || n.Prefix != g.cfg.Prefix {
+			return nil
+		}
+		c, err := g.tagCommitHash(t)
+		if err != nil {
+			return err
+		}
+		if c == h {
+			out = append(out, n)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loop over tags: %w", err)
+	}
+
+	return out, nil
+}
+
+// versionTag returns the tag with version v, regardless of the prefix of v
+// and the spelling of the tag name. The second return value is false if
+// there is none.
+func (g *Git) versionTag(v semver.Version) (tagRef, bool) {
+	for _, t := range g.tags {
+		if t.version.EQ(v) {
+			return t, true
+		}
+	}
+	return tagRef{}, false
+}
+
+// maxTag returns the tag with the highest version of those kept. Of equal
+// versions the first is returned. The second return value is false if none
+// is kept.
+func maxTag(tags []tagRef, keep func(semver.Version) bool) (tagRef, bool) {
+	var (
+		highest tagRef
+		found   bool
+	)
+	for _, t := range tags {
+		if keep(t.version) && (!found || t.version.GT(highest.version)) {
+			highest, found = t, true
+		}
+	}
+	return highest, found
+}
+
+// promotes checks if v is the final version of the prerelease pre.
+func promotes(v, pre semver.Version) bool {
+	if len(v.Pre) > 0 || len(pre.Pre) == 0 {
+		return false
+	}
+	pre.Finalize()
+	return v.EQ(pre)
+}
+
+// tagCommitHash returns the hash of the commit a tag points to, peeling
+// annotated tags.
+func (g *Git) tagCommitHash(t *plumbing.Reference) (plumbing.Hash, error) {
+	tag, err := g.repo.TagObject(t.Hash())
+	switch err {
+	case nil:
+		c, err := tag.Commit()
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("get commit of tag %s: %w", t.Name().Short(), err)
+		}
+		return c.Hash, nil
+	case plumbing.ErrObjectNotFound:
+		return t.Hash(), nil
+	default:
+		return plumbing.ZeroHash, fmt.Errorf("get tag %s: %w", t.Name().Short(), err)
+	}
+}
+
+func (g *Git) tagMessage(v semver.Version, tmpl string) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultTagMessage
+	}
+	t, err := template.New("message").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parse tag message template: %w", err)
+	}
+
+	var b bytes.Buffer
+	err = t.Execute(&b, struct {
+		Version  string
+		Previous string
+	}{
+		Version:  g.Format(v),
+		Previous: g.Format(g.highest),
+	})
+	if err != nil {
+		return "", fmt.Errorf("execute tag message template: %w", err)
+	}
+
+	return b.String(), nil
+}
+
+// identity returns the signature to use for new tags, looked up like git
+// does for the committer.
+func (g *Git) identity() (*object.Signature, error) {
+	name, email := os.Getenv("GIT_COMMITTER_NAME"), os.Getenv("GIT_COMMITTER_EMAIL")
+
+	var sections []*gitconfig.Section
+	if cfg, err := g.repo.Config(); err == nil {
+		sections = append(sections, cfg.Raw.Section("user"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if f, err := os.Open(filepath.Join(home, ".gitconfig")); err == nil {
+			defer f.Close()
+			global := gitconfig.New()
+			if err := gitconfig.NewDecoder(f).Decode(global); err == nil {
+				sections = append(sections, global.Section("user"))
+			}
+		}
+	}
+	for _, s := range sections {
+		if name == "" {
+			name = s.Option("name")
+		}
+		if email == "" {
+			email = s.Option("email")
+		}
+	}
+
+	if name == "" || email == "" {
+		return nil, errors.New("tagger identity unknown, set user.name and user.email in git config")
+	}
+
+	return &object.Signature{Name: name, Email: email, When: time.Now()}, nil
+}
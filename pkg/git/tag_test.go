@@ -0,0 +1,164 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/softsense/git-semver/pkg/semver"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func TestCreateTag(t *testing.T) {
+	tagger := &object.Signature{Name: "test", Email: "test@example.com"}
+
+	tests := []struct {
+		name    string
+		opts    TagOptions
+		message string
+	}{
+		{
+			name: "lightweight",
+		},
+		{
+			name:    "annotated with default message",
+			opts:    TagOptions{Annotated: true, Tagger: tagger},
+			message: "Release v1.0.1\n",
+		},
+		{
+			name:    "annotated with message template",
+			opts:    TagOptions{Annotated: true, Tagger: tagger, Message: "{{.Version}} (previous {{.Previous}})"},
+			message: "v1.0.1 (previous v1.0.0)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := initRepo(t,
+				testCommit{msg: "first", tag: "v1.0.0"},
+				testCommit{msg: "second"},
+			)
+			g, err := Open(path, Config{Prefix: "v"})
+			require.NoError(t, err)
+
+			n, err := g.Increment(false, false, true, false, false)
+			require.NoError(t, err)
+			ref, err := g.CreateTag(n, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, "refs/tags/v1.0.1", ref.Name().String())
+
+			tag, err := g.repo.TagObject(ref.Hash())
+			if tt.opts.Annotated {
+				require.NoError(t, err)
+				require.Equal(t, tt.message, tag.Message)
+			} else {
+				require.Error(t, err)
+			}
+
+			g, err = Open(path, Config{Prefix: "v"})
+			require.NoError(t, err)
+			require.Equal(t, semver.MustParse("v1.0.1"), g.Highest())
+
+			commits, found, err := g.commitsSinceHighest()
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Empty(t, commits)
+
+			_, err = g.CreateTag(semver.MustParse("v1.0.2"), tt.opts)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "is already tagged as v1.0.1")
+		})
+	}
+}
+
+func TestTags(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "first", tag: "v1.0.0"},
+		testCommit{msg: "second", tag: "v1.1.0-rc.1"},
+		testCommit{msg: "third", tag: "api1.0.0"},
+	)
+	g, err := Open(path, Config{Prefix: "v"})
+	require.NoError(t, err)
+	tagged := time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC)
+	_, err = g.CreateTag(semver.MustParse("v1.1.0"), TagOptions{
+		Annotated: true,
+		Tagger:    &object.Signature{Name: "test", Email: "test@example.com", When: tagged},
+	})
+	require.NoError(t, err)
+
+	hash := func(rev string) string {
+		h, err := g.repo.ResolveRevision(plumbing.Revision(rev))
+		require.NoError(t, err)
+		return h.String()
+	}
+
+	// tags on HEAD are not reachable from the rc
+	g, err = Open(path, Config{Prefix: "v", Ref: "v1.1.0-rc.1", Below: ptr(semver.MustParse("v1.1.0-rc.1"))})
+	require.NoError(t, err)
+	require.Equal(t, semver.MustParse("v1.0.0"), g.Highest())
+
+	tags, err := g.Tags()
+	require.NoError(t, err)
+	require.Len(t, tags, 3)
+
+	require.Equal(t, "v1.0.0", tags[0].Version.String())
+	require.Equal(t, hash("v1.0.0"), tags[0].Hash)
+	require.True(t, tags[0].Date.Equal(time.Date(2021, 5, 3, 12, 1, 0, 0, time.UTC)))
+	require.False(t, tags[0].Annotated)
+	require.True(t, tags[0].Reachable)
+
+	require.Equal(t, "v1.1.0-rc.1", tags[1].Version.String())
+	require.True(t, tags[1].Reachable)
+
+	require.Equal(t, "v1.1.0", tags[2].Version.String())
+	require.Equal(t, hash("HEAD"), tags[2].Hash)
+	require.True(t, tags[2].Date.Equal(tagged))
+	require.True(t, tags[2].Annotated)
+	require.False(t, tags[2].Reachable)
+}
+
+func TestCreateTagOtherPrefix(t *testing.T) {
+	path := initRepo(t,
+		testCommit{msg: "first", tag: "v1.0.0"},
+		testCommit{msg: "second", tag: "api1.0.0"},
+	)
+	g, err := Open(path, Config{Prefix: "v"})
+	require.NoError(t, err)
+
+	ref, err := g.CreateTag(semver.MustParse("v1.0.1"), TagOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "v1.0.1", ref.Name().Short())
+}
+
+func TestPushTag(t *testing.T) {
+	remote := t.TempDir()
+	_, err := git.PlainInit(remote, true)
+	require.NoError(t, err)
+
+	path := initRepo(t, testCommit{msg: "first", tag: "v1.0.0"}, testCommit{msg: "second"})
+	g, err := Open(path, Config{Prefix: "v"})
+	require.NoError(t, err)
+	_, err = g.repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remote}})
+	require.NoError(t, err)
+
+	// the tagged commit has to exist on the remote
+	require.NoError(t, g.repo.Push(&git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{"refs/heads/master:refs/heads/master"},
+	}))
+
+	ref, err := g.CreateTag(semver.MustParse("v1.0.1"), TagOptions{})
+	require.NoError(t, err)
+	require.NoError(t, g.PushTag("origin", ref))
+
+	r, err := git.PlainOpen(remote)
+	require.NoError(t, err)
+	got, err := r.Tag("v1.0.1")
+	require.NoError(t, err)
+	require.Equal(t, ref.Hash(), got.Hash())
+
+	require.Error(t, g.PushTag("does-not-exist", ref))
+}
@@ -0,0 +1,104 @@
+// Package gomod checks Go module paths against the major version of a
+// release, see https://go.dev/ref/mod#major-version-suffixes.
+package gomod
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	majorSuffix  = regexp.MustCompile(`/v([0-9]+)$`)
+	gopkgInMajor = regexp.MustCompile(`\.v([0-9]+)(?:-unstable)?$`)
+)
+
+// ModulePath returns the module path declared by the module directive of a
+// go.mod file.
+func ModulePath(data []byte) (string, error) {
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := s.Text()
+		if i := strings.Index(line, "//"); i != -1 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != "module" {
+			continue
+		}
+		if len(fields) != 2 {
+			return "", fmt.Errorf("invalid module directive %q", strings.TrimSpace(line))
+		}
+		path := fields[1]
+		if strings.HasPrefix(path, `"`) || strings.HasPrefix(path, "`") {
+			p, err := strconv.Unquote(path)
+			if err != nil {
+				return "", fmt.Errorf("invalid module path %s: %w", path, err)
+			}
+			path = p
+		}
+		return path, nil
+	}
+	if err := s.Err(); err != nil {
+		return "", fmt.Errorf("read go.mod: %w", err)
+	}
+	return "", errors.New("no module directive found")
+}
+
+// Major returns the major version the module path is for: N for a /vN
+// suffix, or .vN for gopkg.in paths, and 1 for paths without a suffix.
+func Major(path string) uint64 {
+	re := majorSuffix
+	if strings.HasPrefix(path, "gopkg.in/") {
+		re = gopkgInMajor
+	}
+	m := re.FindStringSubmatch(path)
+	if m == nil {
+		return 1
+	}
+	n, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return 1
+	}
+	return n
+}
+
+// PathForMajor returns the module path with the major version suffix for
+// major, e.g. example.com/foo/v2 for example.com/foo and 2.
+func PathForMajor(path string, major uint64) string {
+	if strings.HasPrefix(path, "gopkg.in/") {
+		base := gopkgInMajor.ReplaceAllString(path, "")
+		return fmt.Sprintf("%s.v%d", base, major)
+	}
+	base := majorSuffix.ReplaceAllString(path, "")
+	if major < 2 {
+		return base
+	}
+	return fmt.Sprintf("%s/v%d", base, major)
+}
+
+// CheckMajor checks that the module path matches the major version of a
+// release. Versions 0 and 1 have no suffix, 2 and above need a /vN suffix.
+func CheckMajor(path string, major uint64) error {
+	want := PathForMajor(path, major)
+	if path == want {
+		return nil
+	}
+
+	have := fmt.Sprintf("v%d", Major(path))
+	if PathForMajor(path, 1) == path && !strings.HasPrefix(path, "gopkg.in/") {
+		have = "v0 or v1"
+	}
+	if major < 2 && !strings.HasPrefix(path, "gopkg.in/") {
+		return fmt.Errorf("go.mod declares module %s, which is for %s, but the version is v%d: "+
+			"remove the /%s suffix from the module directive and the import paths, or release %s",
+			path, have, major, have, have)
+	}
+	return fmt.Errorf("go.mod declares module %s, which is for %s, but the version is v%d: "+
+		"change the module directive to %s and update the import paths",
+		path, have, major, want)
+}
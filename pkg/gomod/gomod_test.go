@@ -0,0 +1,95 @@
+package gomod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestModulePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		expect  string
+		wantErr string
+	}{
+		{
+			name:   "plain",
+			data:   "module example.com/foo\n\ngo 1.23\n",
+			expect: "example.com/foo",
+		},
+		{
+			name:   "comments and quotes",
+			data:   "// Deprecated: use bar\nmodule \"example.com/foo/v2\" // v2\n\nrequire example.com/module v1.0.0\n",
+			expect: "example.com/foo/v2",
+		},
+		{
+			name:    "missing",
+			data:    "go 1.23\n",
+			wantErr: "no module directive found",
+		},
+		{
+			name:    "invalid",
+			data:    "module a b\n",
+			wantErr: `invalid module directive "module a b"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ModulePath([]byte(tt.data))
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, got)
+		})
+	}
+}
+
+func TestCheckMajor(t *testing.T) {
+	tests := []struct {
+		path    string
+		major   uint64
+		wantErr string
+	}{
+		{path: "example.com/foo", major: 0},
+		{path: "example.com/foo", major: 1},
+		{path: "example.com/foo/v2", major: 2},
+		{path: "example.com/foo/v10", major: 10},
+		{path: "gopkg.in/yaml.v3", major: 3},
+		{path: "gopkg.in/yaml.v1", major: 1},
+		{
+			path:    "example.com/foo",
+			major:   2,
+			wantErr: "go.mod declares module example.com/foo, which is for v0 or v1, but the version is v2: change the module directive to example.com/foo/v2 and update the import paths",
+		},
+		{
+			path:    "example.com/foo/v2",
+			major:   3,
+			wantErr: "go.mod declares module example.com/foo/v2, which is for v2, but the version is v3: change the module directive to example.com/foo/v3 and update the import paths",
+		},
+		{
+			path:    "example.com/foo/v2",
+			major:   1,
+			wantErr: "go.mod declares module example.com/foo/v2, which is for v2, but the version is v1: remove the /v2 suffix from the module directive and the import paths, or release v2",
+		},
+		{
+			path:    "gopkg.in/yaml.v2",
+			major:   3,
+			wantErr: "go.mod declares module gopkg.in/yaml.v2, which is for v2, but the version is v3: change the module directive to gopkg.in/yaml.v3 and update the import paths",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := CheckMajor(tt.path, tt.major)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,326 @@
+// Package hosting recognizes git hosting services from remote URLs and links
+// pull request and issue references in commit messages.
+package hosting
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Provider is a git hosting service.
+type Provider string
+
+const (
+	GitHub    Provider = "github"
+	GitLab    Provider = "gitlab"
+	Bitbucket Provider = "bitbucket"
+	Gitea     Provider = "gitea"
+	Azure     Provider = "azure"
+)
+
+// ErrUnknownHost is returned by ParseRemote if the host of a remote is not a
+// known hosting service or a configured Host.
+var ErrUnknownHost = errors.New("unknown git host")
+
+// provider describes how a hosting service references pull requests in
+// commit messages and where they are.
+type provider struct {
+	// references match pull request references, the "ref" group is linked
+	// and the "num" group is the number
+	references []*regexp.Regexp
+
+	// template is the default PullRequestURL template
+	template string
+}
+
+var providers = map[Provider]provider{
+	GitHub: {
+		references: []*regexp.Regexp{
+			regexp.MustCompile(`(?m)(?P<ref>\(#(?P<num>[0-9]+)\))$`),
+			regexp.MustCompile(`(?m)^Merge pull request (?P<ref>#(?P<num>[0-9]+))`),
+		},
+		template: "{{.URL}}/pull/{{.Number}}",
+	},
+	GitLab: {
+		references: []*regexp.Regexp{
+			regexp.MustCompile(`(?:^|[\s(])(?P<ref>!(?P<num>[0-9]+))\b`),
+			regexp.MustCompile(`merge request [\w./-]+(?P<ref>!(?P<num>[0-9]+))\b`),
+		},
+		template: "{{.URL}}/-/merge_requests/{{.Number}}",
+	},
+	Bitbucket: {
+		references: []*regexp.Regexp{
+			regexp.MustCompile(`(?P<ref>\(pull request #(?P<num>[0-9]+)\))`),
+		},
+		template: "{{.URL}}/pull-requests/{{.Number}}",
+	},
+	Gitea: {
+		references: []*regexp.Regexp{
+			regexp.MustCompile(`(?m)(?P<ref>\(#(?P<num>[0-9]+)\))$`),
+		},
+		template: "{{.URL}}/pulls/{{.Number}}",
+	},
+	Azure: {
+		references: []*regexp.Regexp{
+			regexp.MustCompile(`(?m)^Merged (?P<ref>PR (?P<num>[0-9]+))`),
+		},
+		template: "{{.URL}}/pullrequest/{{.Number}}",
+	},
+}
+
+// knownHosts maps the hosts of public hosting services to their provider.
+var knownHosts = map[string]Provider{
+	"github.com":        GitHub,
+	"gitlab.com":        GitLab,
+	"bitbucket.org":     Bitbucket,
+	"gitea.com":         Gitea,
+	"codeberg.org":      Gitea,
+	"dev.azure.com":     Azure,
+	"ssh.dev.azure.com": Azure,
+}
+
+// scpLike matches remotes in the scp-like ssh syntax, user@host:path.
+var scpLike = regexp.MustCompile(`^(?:[\w.-]+@)?([\w.-]+):(.+)$`)
+
+// ParseProvider parses a provider name.
+func ParseProvider(s string) (Provider, error) {
+	p := Provider(strings.ToLower(s))
+	if _, ok := providers[p]; !ok {
+		return "", fmt.Errorf("invalid git host provider %q, expected github, gitlab, bitbucket, gitea or azure", s)
+	}
+	return p, nil
+}
+
+// Host maps a self-hosted service, like a GitLab or Gitea instance, to its
+// provider.
+type Host struct {
+	// Name of the host, without port
+	Name string
+
+	// Provider of the service on the host
+	Provider Provider
+
+	// Template overrides the pull request URL template of the provider,
+	// see Repo.PullRequestURL
+	Template string
+}
+
+// ParseHost parses a host from "name=provider" or "name=provider=template".
+func ParseHost(s string) (Host, error) {
+	name, rest, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return Host{}, fmt.Errorf("invalid git host %q, expected host=provider", s)
+	}
+	p, tmpl, _ := strings.Cut(rest, "=")
+	provider, err := ParseProvider(p)
+	if err != nil {
+		return Host{}, err
+	}
+	h := Host{Name: strings.ToLower(name), Provider: provider, Template: tmpl}
+	if err := h.Validate(); err != nil {
+		return Host{}, err
+	}
+	return h, nil
+}
+
+// String returns h as "name=provider" or "name=provider=template".
+func (h Host) String() string {
+	s := h.Name + "=" + string(h.Provider)
+	if h.Template != "" {
+		s += "=" + h.Template
+	}
+	return s
+}
+
+// Validate checks the provider and the template of h.
+func (h Host) Validate() error {
+	if h.Name == "" {
+		return errors.New("git host has no name")
+	}
+	if _, err := ParseProvider(string(h.Provider)); err != nil {
+		return err
+	}
+	if h.Template != "" {
+		if _, err := template.New("url").Parse(h.Template); err != nil {
+			return fmt.Errorf("parse pull request URL template: %w", err)
+		}
+	}
+	return nil
+}
+
+// Repo is a repository on a hosting service.
+type Repo struct {
+	Provider Provider
+
+	// Host is the name of the host, without port
+	Host string
+
+	// Path of the repository on the host, e.g. "owner/repo"
+	Path string
+
+	// URL of the repository web page, e.g. "https://github.com/owner/repo"
+	URL string
+
+	template *template.Template
+}
+
+// ParseRemote recognizes the hosting service of a remote URL, in the
+// scp-like "git@host:owner/repo.git", the "ssh://git@host:port/owner/repo"
+// or the "https://host/owner/repo" syntax. hosts are checked before the
+// public services github.com, gitlab.com, bitbucket.org, gitea.com,
+// codeberg.org and Azure DevOps. ErrUnknownHost is returned if none
+// matches.
+func ParseRemote(remote string, hosts []Host) (*Repo, error) {
+	host, port, p, err := splitRemote(remote)
+	if err != nil {
+		return nil, err
+	}
+	p = strings.TrimSuffix(strings.Trim(p, "/"), ".git")
+
+	r := &Repo{Host: host, Path: p}
+	tmpl := ""
+	for _, h := range hosts {
+		if h.Name == host {
+			r.Provider, tmpl = h.Provider, h.Template
+			break
+		}
+	}
+	if r.Provider == "" {
+		switch {
+		case knownHosts[host] != "":
+			r.Provider = knownHosts[host]
+		case strings.HasSuffix(host, ".visualstudio.com"):
+			r.Provider = Azure
+		default:
+			return nil, fmt.Errorf("%w %s", ErrUnknownHost, host)
+		}
+	}
+
+	base := "https://" + host
+	if port != "" {
+		base += ":" + port
+	}
+	if r.Provider == Azure {
+		base, r.Path = azureURL(host, base, r.Path)
+	}
+	r.URL = base + "/" + r.Path
+
+	if tmpl == "" {
+		tmpl = providers[r.Provider].template
+	}
+	r.template, err = template.New("url").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("parse pull request URL template: %w", err)
+	}
+
+	return r, nil
+}
+
+// PullRequestURL returns the URL of pull request n, from the template of
+// the host or the provider. Templates get the fields URL, Host, Path and
+// Number.
+func (r *Repo) PullRequestURL(n int) (string, error) {
+	var b bytes.Buffer
+	err := r.template.Execute(&b, struct {
+		URL    string
+		Host   string
+		Path   string
+		Number int
+	}{r.URL, r.Host, r.Path, n})
+	if err != nil {
+		return "", fmt.Errorf("render pull request URL: %w", err)
+	}
+	return b.String(), nil
+}
+
+// PullRequest returns the number of the first pull request referenced in
+// msg in the style of the provider, e.g. "(#123)" at the end of a line for
+// GitHub or "!123" for GitLab. The second return value is false if there
+// is none.
+func (r *Repo) PullRequest(msg string) (int, bool) {
+	refs, err := r.PullRequests(msg)
+	if err != nil || len(refs) == 0 {
+		return 0, false
+	}
+	n, _ := strconv.Atoi(refs[0].ID)
+	return n, true
+}
+
+// PullRequests returns the pull request references in msg, ordered by
+// position.
+func (r *Repo) PullRequests(msg string) ([]Reference, error) {
+	var refs []Reference
+	for _, re := range providers[r.Provider].references {
+		ref, num := re.SubexpIndex("ref"), re.SubexpIndex("num")
+		for _, m := range re.FindAllStringSubmatchIndex(msg, -1) {
+			id := msg[m[2*num]:m[2*num+1]]
+			n, err := strconv.Atoi(id)
+			if err != nil {
+				return nil, fmt.Errorf("parse pull request number: %w", err)
+			}
+			u, err := r.PullRequestURL(n)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, Reference{ID: id, URL: u, Start: m[2*ref], End: m[2*ref+1]})
+		}
+	}
+	sortReferences(refs)
+	return refs, nil
+}
+
+// LinkPullRequests replaces the pull request references in msg with
+// Markdown links, e.g. "(#123)" with "[(#123)](https://github.com/owner/repo/pull/123)".
+func (r *Repo) LinkPullRequests(msg string) (string, error) {
+	refs, err := r.PullRequests(msg)
+	if err != nil {
+		return "", err
+	}
+	return Link(msg, refs), nil
+}
+
+// splitRemote returns the host, port and path of a remote URL.
+func splitRemote(remote string) (host, port, p string, err error) {
+	if !strings.Contains(remote, "://") {
+		m := scpLike.FindStringSubmatch(remote)
+		if m == nil {
+			return "", "", "", fmt.Errorf("invalid remote URL %q", remote)
+		}
+		return strings.ToLower(m[1]), "", m[2], nil
+	}
+
+	u, err := url.Parse(remote)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid remote URL %q: %w", remote, err)
+	}
+	if u.Hostname() == "" {
+		return "", "", "", fmt.Errorf("invalid remote URL %q: no host", remote)
+	}
+	// the ssh port says nothing about the port of the web interface
+	if u.Scheme == "http" || u.Scheme == "https" {
+		port = u.Port()
+	}
+	return strings.ToLower(u.Hostname()), port, u.Path, nil
+}
+
+// azureURL returns the web URL and the repository path of an Azure DevOps
+// remote. ssh remotes have the path "v3/org/project/repo", https remotes
+// "org/project/_git/repo" on dev.azure.com and "project/_git/repo" on
+// org.visualstudio.com.
+func azureURL(host, base, p string) (string, string) {
+	parts := strings.Split(p, "/")
+	if len(parts) != 4 || parts[0] != "v3" {
+		return base, p
+	}
+	org, project, repo := parts[1], parts[2], parts[3]
+	if strings.HasSuffix(host, ".visualstudio.com") {
+		return "https://" + org + ".visualstudio.com", project + "/_git/" + repo
+	}
+	return "https://dev.azure.com", org + "/" + project + "/_git/" + repo
+}
@@ -0,0 +1,200 @@
+package hosting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemote(t *testing.T) {
+	hosts := []Host{
+		{Name: "git.example.com", Provider: Gitea},
+		{Name: "gitlab.example.com", Provider: GitLab},
+	}
+
+	tests := []struct {
+		remote  string
+		expect  Repo
+		pr      string
+		wantErr string
+	}{
+		{
+			remote: "git@github.com:foo/bar.git",
+			expect: Repo{Provider: GitHub, Host: "github.com", Path: "foo/bar", URL: "https://github.com/foo/bar"},
+			pr:     "https://github.com/foo/bar/pull/12",
+		},
+		{
+			remote: "https://github.com/foo/bar",
+			expect: Repo{Provider: GitHub, Host: "github.com", Path: "foo/bar", URL: "https://github.com/foo/bar"},
+			pr:     "https://github.com/foo/bar/pull/12",
+		},
+		{
+			remote: "ssh://git@gitlab.com:2222/group/sub/proj.git",
+			expect: Repo{Provider: GitLab, Host: "gitlab.com", Path: "group/sub/proj", URL: "https://gitlab.com/group/sub/proj"},
+			pr:     "https://gitlab.com/group/sub/proj/-/merge_requests/12",
+		},
+		{
+			remote: "https://user@bitbucket.org/team/repo.git",
+			expect: Repo{Provider: Bitbucket, Host: "bitbucket.org", Path: "team/repo", URL: "https://bitbucket.org/team/repo"},
+			pr:     "https://bitbucket.org/team/repo/pull-requests/12",
+		},
+		{
+			remote: "https://codeberg.org/foo/bar.git",
+			expect: Repo{Provider: Gitea, Host: "codeberg.org", Path: "foo/bar", URL: "https://codeberg.org/foo/bar"},
+			pr:     "https://codeberg.org/foo/bar/pulls/12",
+		},
+		{
+			remote: "git@ssh.dev.azure.com:v3/org/project/repo",
+			expect: Repo{Provider: Azure, Host: "ssh.dev.azure.com", Path: "org/project/_git/repo", URL: "https://dev.azure.com/org/project/_git/repo"},
+			pr:     "https://dev.azure.com/org/project/_git/repo/pullrequest/12",
+		},
+		{
+			remote: "https://org@dev.azure.com/org/project/_git/repo",
+			expect: Repo{Provider: Azure, Host: "dev.azure.com", Path: "org/project/_git/repo", URL: "https://dev.azure.com/org/project/_git/repo"},
+			pr:     "https://dev.azure.com/org/project/_git/repo/pullrequest/12",
+		},
+		{
+			remote: "org@vs-ssh.visualstudio.com:v3/org/project/repo",
+			expect: Repo{Provider: Azure, Host: "vs-ssh.visualstudio.com", Path: "project/_git/repo", URL: "https://org.visualstudio.com/project/_git/repo"},
+			pr:     "https://org.visualstudio.com/project/_git/repo/pullrequest/12",
+		},
+		{
+			remote: "https://git.example.com:8443/foo/bar.git",
+			expect: Repo{Provider: Gitea, Host: "git.example.com", Path: "foo/bar", URL: "https://git.example.com:8443/foo/bar"},
+			pr:     "https://git.example.com:8443/foo/bar/pulls/12",
+		},
+		{
+			remote: "ssh://git@gitlab.example.com:2222/foo/bar.git",
+			expect: Repo{Provider: GitLab, Host: "gitlab.example.com", Path: "foo/bar", URL: "https://gitlab.example.com/foo/bar"},
+			pr:     "https://gitlab.example.com/foo/bar/-/merge_requests/12",
+		},
+		{
+			remote:  "git@example.com:foo/bar.git",
+			wantErr: "unknown git host example.com",
+		},
+		{
+			remote:  "/srv/git/repo.git",
+			wantErr: `invalid remote URL "/srv/git/repo.git"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remote, func(t *testing.T) {
+			r, err := ParseRemote(tt.remote, hosts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			pr, err := r.PullRequestURL(12)
+			require.NoError(t, err)
+			require.Equal(t, tt.pr, pr)
+
+			r.template = nil
+			require.Equal(t, tt.expect, *r)
+		})
+	}
+}
+
+func TestLinkPullRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		remote string
+		hosts  []Host
+		msg    string
+		expect string
+		pr     int
+	}{
+		{
+			name:   "github squash",
+			remote: "git@github.com:foo/bar.git",
+			msg:    "Some commit message (#1)\n\nSome description",
+			expect: "Some commit message [(#1)](https://github.com/foo/bar/pull/1)\n\nSome description",
+			pr:     1,
+		},
+		{
+			name:   "github merge",
+			remote: "git@github.com:foo/bar.git",
+			msg:    "Merge pull request #7 from foo/branch",
+			expect: "Merge pull request [#7](https://github.com/foo/bar/pull/7) from foo/branch",
+			pr:     7,
+		},
+		{
+			name:   "github issue reference",
+			remote: "git@github.com:foo/bar.git",
+			msg:    "Fix #3 in parser",
+			expect: "Fix #3 in parser",
+		},
+		{
+			name:   "gitlab",
+			remote: "git@gitlab.com:foo/bar.git",
+			msg:    "Add feature (!12)\n\nSee merge request foo/bar!12",
+			expect: "Add feature ([!12](https://gitlab.com/foo/bar/-/merge_requests/12))\n\nSee merge request foo/bar[!12](https://gitlab.com/foo/bar/-/merge_requests/12)",
+			pr:     12,
+		},
+		{
+			name:   "gitlab issue reference",
+			remote: "git@gitlab.com:foo/bar.git",
+			msg:    "Add feature (#12)",
+			expect: "Add feature (#12)",
+		},
+		{
+			name:   "bitbucket",
+			remote: "git@bitbucket.org:foo/bar.git",
+			msg:    "Merged in feature (pull request #5)",
+			expect: "Merged in feature [(pull request #5)](https://bitbucket.org/foo/bar/pull-requests/5)",
+			pr:     5,
+		},
+		{
+			name:   "azure",
+			remote: "https://dev.azure.com/org/project/_git/repo",
+			msg:    "Merged PR 42: Add feature",
+			expect: "Merged [PR 42](https://dev.azure.com/org/project/_git/repo/pullrequest/42): Add feature",
+			pr:     42,
+		},
+		{
+			name:   "custom template",
+			remote: "git@git.example.com:foo/bar.git",
+			hosts:  []Host{{Name: "git.example.com", Provider: Gitea, Template: "https://review.example.com/{{.Path}}/{{.Number}}"}},
+			msg:    "Add feature (#3)",
+			expect: "Add feature [(#3)](https://review.example.com/foo/bar/3)",
+			pr:     3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRemote(tt.remote, tt.hosts)
+			require.NoError(t, err)
+
+			got, err := r.LinkPullRequests(tt.msg)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, got)
+
+			n, ok := r.PullRequest(tt.msg)
+			require.Equal(t, tt.pr != 0, ok)
+			require.Equal(t, tt.pr, n)
+		})
+	}
+}
+
+func TestParseHost(t *testing.T) {
+	h, err := ParseHost("Git.Example.com=gitea")
+	require.NoError(t, err)
+	require.Equal(t, Host{Name: "git.example.com", Provider: Gitea}, h)
+
+	h, err = ParseHost("git.example.com=gitlab=https://review.example.com/{{.Path}}?id={{.Number}}")
+	require.NoError(t, err)
+	require.Equal(t, Host{Name: "git.example.com", Provider: GitLab, Template: "https://review.example.com/{{.Path}}?id={{.Number}}"}, h)
+	require.Equal(t, "git.example.com=gitlab=https://review.example.com/{{.Path}}?id={{.Number}}", h.String())
+
+	_, err = ParseHost("git.example.com=gitea={{.Number")
+	require.ErrorContains(t, err, "parse pull request URL template")
+
+	_, err = ParseHost("git.example.com")
+	require.EqualError(t, err, `invalid git host "git.example.com", expected host=provider`)
+
+	_, err = ParseHost("git.example.com=gogs")
+	require.EqualError(t, err, `invalid git host provider "gogs", expected github, gitlab, bitbucket, gitea or azure`)
+}
@@ -0,0 +1,131 @@
+package hosting
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Reference is a pull request or an issue referenced in a commit message.
+type Reference struct {
+	// ID of the pull request or issue, e.g. "123" or "PROJ-1234"
+	ID string
+
+	// URL of the pull request or issue
+	URL string
+
+	// Start and End are the byte offsets of the reference in the message
+	Start, End int
+}
+
+// Link replaces the references in msg with Markdown links to their URL.
+// References overlapping an earlier one in refs are left out.
+func Link(msg string, refs []Reference) string {
+	var kept []Reference
+	for _, r := range refs {
+		overlaps := false
+		for _, k := range kept {
+			if r.Start < k.End && k.Start < r.End {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			kept = append(kept, r)
+		}
+	}
+	sortReferences(kept)
+
+	var b strings.Builder
+	last := 0
+	for _, r := range kept {
+		fmt.Fprintf(&b, "%s[%s](%s)", msg[last:r.Start], msg[r.Start:r.End], r.URL)
+		last = r.End
+	}
+	b.WriteString(msg[last:])
+	return b.String()
+}
+
+// IssueTracker finds issue references like Jira keys or "Fixes #12" in
+// commit messages and links them.
+type IssueTracker struct {
+	pattern  *regexp.Regexp
+	tmpl     string
+	template *template.Template
+}
+
+// NewIssueTracker returns an issue tracker finding references matching the
+// regular expression pattern. The "id" group of pattern is the issue ID,
+// the whole match if there is none, and the "ref" group is linked, the
+// whole match if there is none. Issue URLs are rendered from tmpl, getting
+// the fields ID, and URL, Host and Path of the repository on its hosting
+// service, e.g. "https://jira.example.com/browse/{{.ID}}" or
+// "{{.URL}}/issues/{{.ID}}".
+func NewIssueTracker(pattern, tmpl string) (IssueTracker, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return IssueTracker{}, fmt.Errorf("parse issue pattern: %w", err)
+	}
+	t, err := template.New("url").Parse(tmpl)
+	if err != nil {
+		return IssueTracker{}, fmt.Errorf("parse issue URL template: %w", err)
+	}
+	return IssueTracker{pattern: re, tmpl: tmpl, template: t}, nil
+}
+
+// ParseIssueTracker parses an issue tracker from "pattern=template", see
+// NewIssueTracker. The pattern cannot contain "=".
+func ParseIssueTracker(s string) (IssueTracker, error) {
+	pattern, tmpl, ok := strings.Cut(s, "=")
+	if !ok || pattern == "" || tmpl == "" {
+		return IssueTracker{}, fmt.Errorf("invalid issue tracker %q, expected pattern=template", s)
+	}
+	return NewIssueTracker(pattern, tmpl)
+}
+
+// String returns t as "pattern=template".
+func (t IssueTracker) String() string {
+	return t.pattern.String() + "=" + t.tmpl
+}
+
+// References returns the issue references in msg, ordered by position.
+// repo is the repository for the URL template, it may be nil.
+func (t IssueTracker) References(msg string, repo *Repo) ([]Reference, error) {
+	if repo == nil {
+		repo = &Repo{}
+	}
+	id, ref := t.pattern.SubexpIndex("id"), t.pattern.SubexpIndex("ref")
+
+	var refs []Reference
+	for _, m := range t.pattern.FindAllStringSubmatchIndex(msg, -1) {
+		r := Reference{Start: m[0], End: m[1]}
+		if ref != -1 && m[2*ref] != -1 {
+			r.Start, r.End = m[2*ref], m[2*ref+1]
+		}
+		r.ID = msg[m[0]:m[1]]
+		if id != -1 && m[2*id] != -1 {
+			r.ID = msg[m[2*id]:m[2*id+1]]
+		}
+
+		var b bytes.Buffer
+		err := t.template.Execute(&b, struct {
+			ID   string
+			URL  string
+			Host string
+			Path string
+		}{r.ID, repo.URL, repo.Host, repo.Path})
+		if err != nil {
+			return nil, fmt.Errorf("render issue URL: %w", err)
+		}
+		r.URL = b.String()
+		refs = append(refs, r)
+	}
+	return refs, nil
+}
+
+func sortReferences(refs []Reference) {
+	sort.SliceStable(refs, func(i, j int) bool { return refs[i].Start < refs[j].Start })
+}
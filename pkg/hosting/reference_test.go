@@ -0,0 +1,82 @@
+package hosting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueTracker(t *testing.T) {
+	jira, err := NewIssueTracker(`\b[A-Z][A-Z0-9]+-[0-9]+\b`, "https://jira.example.com/browse/{{.ID}}")
+	require.NoError(t, err)
+	fixes, err := ParseIssueTracker(`(?i)\b(?:fix(?:es|ed)?|close[sd]?|resolve[sd]?) (?P<ref>#(?P<id>[0-9]+))={{.URL}}/issues/{{.ID}}`)
+	require.NoError(t, err)
+	github, err := ParseRemote("git@github.com:foo/bar.git", nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		tracker IssueTracker
+		msg     string
+		expect  []Reference
+		linked  string
+	}{
+		{
+			name:    "jira",
+			tracker: jira,
+			msg:     "PROJ-12: fix parser\n\nAlso OPS-3.",
+			expect: []Reference{
+				{ID: "PROJ-12", URL: "https://jira.example.com/browse/PROJ-12", Start: 0, End: 7},
+				{ID: "OPS-3", URL: "https://jira.example.com/browse/OPS-3", Start: 26, End: 31},
+			},
+			linked: "[PROJ-12](https://jira.example.com/browse/PROJ-12): fix parser\n\nAlso [OPS-3](https://jira.example.com/browse/OPS-3).",
+		},
+		{
+			name:    "github issues",
+			tracker: fixes,
+			msg:     "fix: parser\n\nFixes #12, closes #13 and see #14",
+			expect: []Reference{
+				{ID: "12", URL: "https://github.com/foo/bar/issues/12", Start: 19, End: 22},
+				{ID: "13", URL: "https://github.com/foo/bar/issues/13", Start: 31, End: 34},
+			},
+			linked: "fix: parser\n\nFixes [#12](https://github.com/foo/bar/issues/12), closes [#13](https://github.com/foo/bar/issues/13) and see #14",
+		},
+		{
+			name:    "none",
+			tracker: jira,
+			msg:     "fix: parser",
+			linked:  "fix: parser",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs, err := tt.tracker.References(tt.msg, github)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, refs)
+			require.Equal(t, tt.linked, Link(tt.msg, refs))
+		})
+	}
+
+	require.Equal(t, `\b[A-Z][A-Z0-9]+-[0-9]+\b=https://jira.example.com/browse/{{.ID}}`, jira.String())
+}
+
+func TestLinkOverlapping(t *testing.T) {
+	msg := "Add feature (#3)"
+	refs := []Reference{
+		{ID: "3", URL: "https://example.com/pulls/3", Start: 12, End: 16},
+		{ID: "3", URL: "https://example.com/issues/3", Start: 13, End: 15},
+	}
+	require.Equal(t, "Add feature [(#3)](https://example.com/pulls/3)", Link(msg, refs))
+}
+
+func TestParseIssueTracker(t *testing.T) {
+	_, err := ParseIssueTracker("PROJ-[0-9]+")
+	require.EqualError(t, err, `invalid issue tracker "PROJ-[0-9]+", expected pattern=template`)
+
+	_, err = ParseIssueTracker("PROJ-[0-9+=https://jira.example.com/browse/{{.ID}}")
+	require.ErrorContains(t, err, "parse issue pattern")
+
+	_, err = ParseIssueTracker("PROJ-[0-9]+=https://jira.example.com/browse/{{.ID")
+	require.ErrorContains(t, err, "parse issue URL template")
+}
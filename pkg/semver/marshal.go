@@ -0,0 +1,122 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalText implements encoding.TextMarshaler, the version is encoded as
+// its string including the prefix.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Version) UnmarshalText(text []byte) error {
+	n, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*v = n
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, the version is encoded as a string.
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, null leaves v unchanged.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner for strings and byte slices, NULL scans
+// into the zero value.
+func (v *Version) Scan(src interface{}) error {
+	if src == nil {
+		*v = Version{}
+		return nil
+	}
+	s, err := scanString(src)
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Value implements driver.Valuer.
+func (v Version) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v PRVersion) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *PRVersion) UnmarshalText(text []byte) error {
+	n, err := NewPRVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*v = n
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, the prerelease version is encoded
+// as a string, numeric ones too.
+func (v PRVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, null leaves v unchanged.
+func (v *PRVersion) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner for strings and byte slices, NULL scans
+// into the zero value.
+func (v *PRVersion) Scan(src interface{}) error {
+	if src == nil {
+		*v = PRVersion{}
+		return nil
+	}
+	s, err := scanString(src)
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Value implements driver.Valuer.
+func (v PRVersion) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+func scanString(src interface{}) (string, error) {
+	switch s := src.(type) {
+	case string:
+		return s, nil
+	case []byte:
+		return string(s), nil
+	default:
+		return "", fmt.Errorf("cannot scan %T into a version", src)
+	}
+}
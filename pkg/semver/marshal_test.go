@@ -0,0 +1,156 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var marshalVersions = []string{
+	"1.2.3",
+	"v1.2.3",
+	"api/v1.2.3-rc.1",
+	"1.2.3-beta.2+build.5",
+	"0.0.0",
+}
+
+func TestVersion_MarshalJSON(t *testing.T) {
+	type doc struct {
+		Version Version `json:"version"`
+	}
+
+	for _, s := range marshalVersions {
+		t.Run(s, func(t *testing.T) {
+			data, err := json.Marshal(doc{Version: MustParse(s)})
+			require.NoError(t, err)
+			require.JSONEq(t, `{"version":"`+s+`"}`, string(data))
+
+			var got doc
+			require.NoError(t, json.Unmarshal(data, &got))
+			require.Equal(t, MustParse(s), got.Version)
+			require.Equal(t, s, got.Version.String())
+		})
+	}
+
+	var v Version
+	require.EqualError(t, json.Unmarshal([]byte(`"1.2"`), &v), "no Major.Minor.Patch elements found")
+	require.Error(t, json.Unmarshal([]byte(`123`), &v))
+}
+
+func TestVersion_MarshalJSONNull(t *testing.T) {
+	type doc struct {
+		Version *Version `json:"version"`
+		Pinned  Version  `json:"pinned"`
+	}
+
+	got := doc{Pinned: MustParse("1.2.3")}
+	require.NoError(t, json.Unmarshal([]byte(`{"version":null,"pinned":null}`), &got))
+	require.Nil(t, got.Version)
+	require.Equal(t, MustParse("1.2.3"), got.Pinned)
+
+	data, err := json.Marshal(doc{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"version":null,"pinned":"0.0.0"}`, string(data))
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Nil(t, got.Version)
+	require.Equal(t, Version{}, got.Pinned)
+}
+
+func TestVersion_MarshalText(t *testing.T) {
+	for _, s := range marshalVersions {
+		t.Run(s, func(t *testing.T) {
+			data, err := MustParse(s).MarshalText()
+			require.NoError(t, err)
+			require.Equal(t, s, string(data))
+
+			var got Version
+			require.NoError(t, got.UnmarshalText(data))
+			require.Equal(t, MustParse(s), got)
+		})
+	}
+}
+
+func TestVersion_MarshalYAML(t *testing.T) {
+	type doc struct {
+		Version Version `yaml:"version"`
+	}
+
+	for _, s := range marshalVersions {
+		t.Run(s, func(t *testing.T) {
+			data, err := yaml.Marshal(doc{Version: MustParse(s)})
+			require.NoError(t, err)
+
+			var got doc
+			require.NoError(t, yaml.Unmarshal(data, &got))
+			require.Equal(t, MustParse(s), got.Version)
+		})
+	}
+}
+
+func TestVersion_SQL(t *testing.T) {
+	for _, s := range marshalVersions {
+		t.Run(s, func(t *testing.T) {
+			value, err := MustParse(s).Value()
+			require.NoError(t, err)
+			require.Equal(t, s, value)
+
+			var fromString, fromBytes Version
+			require.NoError(t, fromString.Scan(value))
+			require.NoError(t, fromBytes.Scan([]byte(s)))
+			require.Equal(t, MustParse(s), fromString)
+			require.Equal(t, MustParse(s), fromBytes)
+		})
+	}
+
+	var v Version
+	require.EqualError(t, v.Scan(42), "cannot scan int into a version")
+
+	v = MustParse("1.2.3")
+	require.NoError(t, v.Scan(nil))
+	require.Equal(t, Version{}, v)
+	value, err := v.Value()
+	require.NoError(t, err)
+	require.NoError(t, v.Scan(value))
+	require.Equal(t, Version{}, v)
+}
+
+func TestPRVersion_Marshal(t *testing.T) {
+	for _, s := range []string{"rc", "rc1", "1", "alpha-2"} {
+		t.Run(s, func(t *testing.T) {
+			pre, err := NewPRVersion(s)
+			require.NoError(t, err)
+
+			data, err := json.Marshal(pre)
+			require.NoError(t, err)
+			require.Equal(t, `"`+s+`"`, string(data))
+
+			var got PRVersion
+			require.NoError(t, json.Unmarshal(data, &got))
+			require.Equal(t, pre, got)
+
+			text, err := pre.MarshalText()
+			require.NoError(t, err)
+			got = PRVersion{}
+			require.NoError(t, got.UnmarshalText(text))
+			require.Equal(t, pre, got)
+
+			value, err := pre.Value()
+			require.NoError(t, err)
+			got = PRVersion{}
+			require.NoError(t, got.Scan(value))
+			require.Equal(t, pre, got)
+		})
+	}
+
+	var pre PRVersion
+	require.EqualError(t, json.Unmarshal([]byte(`"01"`), &pre), `numeric PreRelease version must not contain leading zeroes "01"`)
+	require.EqualError(t, pre.Scan(42), "cannot scan int into a version")
+
+	pre = PRVersion{VersionStr: "rc"}
+	require.NoError(t, json.Unmarshal([]byte(`null`), &pre))
+	require.Equal(t, PRVersion{VersionStr: "rc"}, pre)
+	require.NoError(t, pre.Scan(nil))
+	require.Equal(t, PRVersion{}, pre)
+}
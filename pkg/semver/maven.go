@@ -0,0 +1,123 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	mavenVersion   = regexp.MustCompile(`^(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-(.+))?$`)
+	mavenQualifier = regexp.MustCompile(`(?i)^(alpha|a|beta|b|milestone|m|rc|cr)\.?(\d+)$`)
+)
+
+// mavenLabels maps prerelease labels to Maven qualifiers.
+var mavenLabels = map[string]string{
+	"alpha":     "alpha",
+	"beta":      "beta",
+	"milestone": "M",
+	"rc":        "RC",
+}
+
+// Maven returns v as a Maven artifact version, e.g. 1.2.3-RC1 for 1.2.3-rc.1
+// or 1.2.3-rc1. Development versions, with a dev or snapshot prerelease, end
+// in -SNAPSHOT: 1.2.4-SNAPSHOT for 1.2.4-dev.17+g1a2b3c4. The prefix and
+// build metadata are dropped, other prerelease identifiers are kept.
+func (v Version) Maven() string {
+	var (
+		parts    []string
+		snapshot bool
+	)
+	for i := 0; i < len(v.Pre); i++ {
+		pre := v.Pre[i]
+		if id := strings.ToLower(pre.VersionStr); !pre.IsNum && (id == "dev" || strings.HasPrefix(id, "snapshot")) {
+			snapshot = true
+			if i+1 < len(v.Pre) && v.Pre[i+1].IsNum {
+				i++
+			}
+			continue
+		}
+		m := labelNumber.FindStringSubmatch(pre.VersionStr)
+		if pre.IsNum || m == nil {
+			parts = append(parts, pre.String())
+			continue
+		}
+		label, num := strings.ToLower(strings.TrimSuffix(m[1], "-")), m[2]
+		qualifier, ok := mavenLabels[label]
+		if !ok {
+			parts = append(parts, pre.VersionStr)
+			continue
+		}
+		if num == "" && i+1 < len(v.Pre) && v.Pre[i+1].IsNum {
+			num = v.Pre[i+1].String()
+			i++
+		}
+		parts = append(parts, qualifier+num)
+	}
+	if snapshot {
+		parts = append(parts, "SNAPSHOT")
+	}
+
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(parts) > 0 {
+		s += "-" + strings.Join(parts, "-")
+	}
+	return s
+}
+
+// ParseMaven parses a Maven artifact version into a semantic version, e.g.
+// 1.2.3-RC1 into 1.2.3-rc.1 and 1.2-SNAPSHOT into 1.2.0-SNAPSHOT. Missing
+// minor and patch versions are 0, other qualifiers are split on - and . into
+// prerelease identifiers.
+func ParseMaven(s string) (Version, error) {
+	m := mavenVersion.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Version{}, fmt.Errorf("invalid Maven version %q", s)
+	}
+
+	var nums [3]uint64
+	for i, p := range m[1:4] {
+		if p == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid Maven version %q: %w", s, err)
+		}
+		nums[i] = n
+	}
+	v := Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}
+
+	if m[4] != "" {
+		for _, part := range strings.Split(m[4], "-") {
+			ids := strings.Split(part, ".")
+			if qm := mavenQualifier.FindStringSubmatch(part); qm != nil {
+				ids = []string{mavenLabel(qm[1]), qm[2]}
+			}
+			for _, id := range ids {
+				pre, err := NewPRVersion(id)
+				if err != nil {
+					return Version{}, fmt.Errorf("invalid Maven version %q: %w", s, err)
+				}
+				v.Pre = append(v.Pre, pre)
+			}
+		}
+	}
+
+	return v, nil
+}
+
+// mavenLabel returns the prerelease label of a Maven qualifier.
+func mavenLabel(qualifier string) string {
+	switch strings.ToLower(qualifier) {
+	case "a", "alpha":
+		return "alpha"
+	case "b", "beta":
+		return "beta"
+	case "m", "milestone":
+		return "milestone"
+	default:
+		return "rc"
+	}
+}
@@ -0,0 +1,72 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion_Maven(t *testing.T) {
+	tests := []struct {
+		version string
+		expect  string
+	}{
+		{version: "1.2.3", expect: "1.2.3"},
+		{version: "v1.2.3+build.5", expect: "1.2.3"},
+		{version: "1.2.3-rc.1", expect: "1.2.3-RC1"},
+		{version: "1.2.3-rc1", expect: "1.2.3-RC1"},
+		{version: "1.2.3-alpha.2", expect: "1.2.3-alpha2"},
+		{version: "1.2.3-milestone.1", expect: "1.2.3-M1"},
+		{version: "1.2.4-dev.17+g1a2b3c4", expect: "1.2.4-SNAPSHOT"},
+		{version: "1.2.4-snapshot-1a2b3c4", expect: "1.2.4-SNAPSHOT"},
+		{version: "1.2.4-rc1.dev.3", expect: "1.2.4-RC1-SNAPSHOT"},
+		{version: "1.2.4-foo.7", expect: "1.2.4-foo-7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			require.Equal(t, tt.expect, MustParse(tt.version).Maven())
+		})
+	}
+}
+
+func TestParseMaven(t *testing.T) {
+	tests := []struct {
+		version string
+		expect  string
+		wantErr string
+	}{
+		{version: "1.2.3", expect: "1.2.3"},
+		{version: "1.2", expect: "1.2.0"},
+		{version: "1", expect: "1.0.0"},
+		{version: "1.2.3-RC1", expect: "1.2.3-rc.1"},
+		{version: "1.2.3-cr.2", expect: "1.2.3-rc.2"},
+		{version: "1.2.3-M1", expect: "1.2.3-milestone.1"},
+		{version: "1.2.3-beta-2", expect: "1.2.3-beta.2"},
+		{version: "1.2-SNAPSHOT", expect: "1.2.0-SNAPSHOT"},
+		{version: "1.2.3-RC1-SNAPSHOT", expect: "1.2.3-rc.1.SNAPSHOT"},
+		{version: "1.2.3-jre", expect: "1.2.3-jre"},
+		{version: "1.2.3.4", wantErr: `invalid Maven version "1.2.3.4"`},
+		{version: "1.2.3-RC_1", wantErr: `invalid Maven version "1.2.3-RC_1": invalid character(s) found in prerelease "RC_1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := ParseMaven(tt.version)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, MustParse(tt.expect), got)
+		})
+	}
+}
+
+func TestMavenRoundTrip(t *testing.T) {
+	for _, s := range []string{"1.2.3", "1.2.3-RC1", "1.2.3-alpha2", "1.2.3-M1-SNAPSHOT", "1.2.3-SNAPSHOT"} {
+		v, err := ParseMaven(s)
+		require.NoError(t, err)
+		require.Equal(t, s, v.Maven())
+	}
+}
@@ -0,0 +1,156 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	pep440Version = regexp.MustCompile(`(?i)^v?(\d+(?:\.\d+)*)` +
+		`(?:[-_.]?(a|alpha|b|beta|c|rc|pre|preview)[-_.]?(\d+)?)?` +
+		`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` +
+		`(?:[-_.]?(dev)[-_.]?(\d+)?)?` +
+		`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+	localSeparator = regexp.MustCompile(`[-_.]`)
+	labelNumber    = regexp.MustCompile(`^([a-zA-Z-]*?)(\d*)$`)
+)
+
+// pep440Dev is the index of the dev phase in pep440Phases, which follows the
+// other phases with a dot.
+const pep440Dev = 3
+
+// pep440Phases maps prerelease labels to PEP 440 phases, in the order they
+// must appear.
+var pep440Phases = []struct {
+	phase  string
+	labels []string
+}{
+	{"a", []string{"a", "alpha"}},
+	{"b", []string{"b", "beta"}},
+	{"rc", []string{"c", "rc", "pre", "preview"}},
+	{"dev", []string{"dev"}},
+}
+
+// PEP440 returns v as a Python PEP 440 version, e.g. 1.2.3rc1 for
+// 1.2.3-rc.1 or 1.2.3-rc1, and 1.2.3.dev17+g1a2b3c4 for
+// 1.2.3-dev.17+g1a2b3c4. The prefix is dropped and build metadata becomes
+// the local version. It fails for prerelease identifiers other than alpha,
+// beta, rc and dev, each followed by an optional number.
+func (v Version) PEP440() (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	last := -1
+	for i := 0; i < len(v.Pre); i++ {
+		pre := v.Pre[i]
+		m := labelNumber.FindStringSubmatch(pre.VersionStr)
+		if pre.IsNum || m == nil || m[1] == "" {
+			return "", fmt.Errorf("prerelease %q has no PEP 440 equivalent", v.Pre[i].String())
+		}
+		label, num := strings.ToLower(strings.TrimSuffix(m[1], "-")), m[2]
+		if num == "" && i+1 < len(v.Pre) && v.Pre[i+1].IsNum {
+			num = v.Pre[i+1].String()
+			i++
+		}
+		if num == "" {
+			num = "0"
+		}
+		if n, err := strconv.ParseUint(num, 10, 64); err == nil {
+			num = strconv.FormatUint(n, 10)
+		}
+
+		phase := pep440Phase(label)
+		switch {
+		case phase == -1:
+			return "", fmt.Errorf("prerelease %q has no PEP 440 equivalent", label)
+		case phase <= last || phase < pep440Dev && last >= 0:
+			return "", fmt.Errorf("prerelease %q is out of PEP 440 order", label)
+		case phase < pep440Dev:
+			b.WriteString(pep440Phases[phase].phase + num)
+		default:
+			b.WriteString("." + pep440Phases[phase].phase + num)
+		}
+		last = phase
+	}
+
+	if len(v.Build) > 0 {
+		local := strings.Join(v.Build, ".")
+		b.WriteString("+" + strings.ToLower(localSeparator.ReplaceAllString(local, ".")))
+	}
+
+	return b.String(), nil
+}
+
+// ParsePEP440 parses a Python PEP 440 version into a semantic version,
+// e.g. 1.2.3rc1 into 1.2.3-rc.1 and 1.2.dev3+abc into 1.2.0-dev.3+abc.
+// Missing release segments are 0, epochs and more than three release
+// segments are not supported. Post-releases like 1.2.3.post1 are rejected:
+// they rank above the release, while any prerelease of 1.2.3 ranks below
+// it.
+func ParsePEP440(s string) (Version, error) {
+	m := pep440Version.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Version{}, fmt.Errorf("invalid PEP 440 version %q", s)
+	}
+
+	release := strings.Split(m[1], ".")
+	if len(release) > 3 {
+		return Version{}, fmt.Errorf("PEP 440 version %q has more than three release segments", s)
+	}
+	var nums [3]uint64
+	for i, r := range release {
+		n, err := strconv.ParseUint(r, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid PEP 440 version %q: %w", s, err)
+		}
+		nums[i] = n
+	}
+	v := Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}
+
+	add := func(label, num string) error {
+		if num == "" {
+			num = "0"
+		}
+		n, err := strconv.ParseUint(num, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid PEP 440 version %q: %w", s, err)
+		}
+		v.Pre = append(v.Pre, PRVersion{VersionStr: label}, PRVersion{VersionNum: n, IsNum: true})
+		return nil
+	}
+	if m[2] != "" {
+		label := [...]string{"alpha", "beta", "rc"}[pep440Phase(strings.ToLower(m[2]))]
+		if err := add(label, m[3]); err != nil {
+			return Version{}, err
+		}
+	}
+	if m[4] != "" || m[5] != "" {
+		return Version{}, fmt.Errorf("PEP 440 post-release %q is not supported, it has no semantic version equivalent", s)
+	}
+	if m[7] != "" {
+		if err := add("dev", m[8]); err != nil {
+			return Version{}, err
+		}
+	}
+	if m[9] != "" {
+		v.Build = localSeparator.Split(strings.ToLower(m[9]), -1)
+	}
+
+	if err := v.Validate(); err != nil {
+		return Version{}, err
+	}
+	return v, nil
+}
+
+func pep440Phase(label string) int {
+	for i, p := range pep440Phases {
+		for _, l := range p.labels {
+			if l == label {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,106 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion_PEP440(t *testing.T) {
+	tests := []struct {
+		version string
+		expect  string
+		wantErr string
+	}{
+		{version: "1.2.3", expect: "1.2.3"},
+		{version: "v1.2.3", expect: "1.2.3"},
+		{version: "1.2.3-rc.1", expect: "1.2.3rc1"},
+		{version: "1.2.3-rc1", expect: "1.2.3rc1"},
+		{version: "1.2.3-rc", expect: "1.2.3rc0"},
+		{version: "1.2.3-alpha.2", expect: "1.2.3a2"},
+		{version: "1.2.3-beta-3", expect: "1.2.3b3"},
+		{version: "1.2.3-dev.17+g1a2b3c4", expect: "1.2.3.dev17+g1a2b3c4"},
+		{version: "1.2.3-rc1.dev.17+g1a2b3c4.dirty", expect: "1.2.3rc1.dev17+g1a2b3c4.dirty"},
+		{version: "1.2.3-post.1", wantErr: `prerelease "post" has no PEP 440 equivalent`},
+		{version: "1.2.3+Build-5", expect: "1.2.3+build.5"},
+		{version: "1.2.3-snapshot-1a2b3c4", wantErr: `prerelease "snapshot-1a2b3c4" has no PEP 440 equivalent`},
+		{version: "1.2.3-foo.1", wantErr: `prerelease "foo" has no PEP 440 equivalent`},
+		{version: "1.2.3-dev.1.rc.1", wantErr: `prerelease "rc" is out of PEP 440 order`},
+		{version: "1.2.3-1", wantErr: `prerelease "1" has no PEP 440 equivalent`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := MustParse(tt.version).PEP440()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, got)
+		})
+	}
+}
+
+func TestParsePEP440(t *testing.T) {
+	tests := []struct {
+		version string
+		expect  string
+		wantErr string
+	}{
+		{version: "1.2.3", expect: "1.2.3"},
+		{version: "v1.2", expect: "1.2.0"},
+		{version: "1", expect: "1.0.0"},
+		{version: "1.2.3rc1", expect: "1.2.3-rc.1"},
+		{version: "1.2.3RC1", expect: "1.2.3-rc.1"},
+		{version: "1.2.3-rc.1", expect: "1.2.3-rc.1"},
+		{version: "1.2.3c1", expect: "1.2.3-rc.1"},
+		{version: "1.2.3a", expect: "1.2.3-alpha.0"},
+		{version: "1.2.3b2", expect: "1.2.3-beta.2"},
+		{version: "1.2.3.post4", wantErr: `PEP 440 post-release "1.2.3.post4" is not supported, it has no semantic version equivalent`},
+		{version: "1.2.3-4", wantErr: `PEP 440 post-release "1.2.3-4" is not supported, it has no semantic version equivalent`},
+		{version: "1.2.3.dev17+g1a2b3c4", expect: "1.2.3-dev.17+g1a2b3c4"},
+		{version: "1.2.3rc1.dev17+g1a2b3c4.dirty", expect: "1.2.3-rc.1.dev.17+g1a2b3c4.dirty"},
+		{version: "1.2.3+ubuntu_1", expect: "1.2.3+ubuntu.1"},
+		{version: "1.2.3.4", wantErr: `PEP 440 version "1.2.3.4" has more than three release segments`},
+		{version: "1!1.2.3", wantErr: `invalid PEP 440 version "1!1.2.3"`},
+		{version: "1.2.3-foo", wantErr: `invalid PEP 440 version "1.2.3-foo"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := ParsePEP440(tt.version)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, MustParse(tt.expect), got)
+		})
+	}
+}
+
+func TestPEP440RoundTrip(t *testing.T) {
+	for _, s := range []string{"1.2.3", "1.2.3rc1", "1.2.3a2.dev4", "1.2.3rc1.dev2+abc.5", "0.0.1b0"} {
+		v, err := ParsePEP440(s)
+		require.NoError(t, err)
+		got, err := v.PEP440()
+		require.NoError(t, err)
+		require.Equal(t, s, got)
+	}
+}
+
+func TestParsePEP440Order(t *testing.T) {
+	ordered := []string{"1.2.3rc1", "1.2.3", "1.2.4.dev0", "1.2.4"}
+	for i := 1; i < len(ordered); i++ {
+		a, err := ParsePEP440(ordered[i-1])
+		require.NoError(t, err)
+		b, err := ParsePEP440(ordered[i])
+		require.NoError(t, err)
+		require.True(t, a.LT(b), "%s < %s", ordered[i-1], ordered[i])
+	}
+
+	// 1.2.3.post1 ranks above 1.2.3, no prerelease of 1.2.3 does
+	_, err := ParsePEP440("1.2.3.post1")
+	require.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HasPrerelease checks if v has the prerelease identifier id, either in the
+// dotted "rc.1" or the compact "rc1" style.
+func (v Version) HasPrerelease(id string) bool {
+	return v.prereleaseIndex(id) != -1
+}
+
+// IncrementPrerelease increments the number following the prerelease
+// identifier id, turning 1.2.0-rc.1 into 1.2.0-rc.2 and 1.2.0-rc1 into
+// 1.2.0-rc2. Identifiers after the number and build metadata are dropped.
+// If v has no such prerelease it becomes id.1, without bumping the version.
+func (v *Version) IncrementPrerelease(id string) error {
+	if id == "" || !containsOnly(id, alphas) {
+		return fmt.Errorf("invalid prerelease identifier %q", id)
+	}
+
+	pre := append([]PRVersion(nil), v.Pre...)
+	i := v.prereleaseIndex(id)
+	switch {
+	case i == -1:
+		pre = []PRVersion{{VersionStr: id}, {VersionNum: 1, IsNum: true}}
+	case pre[i].VersionStr == id:
+		var n uint64
+		if i+1 < len(pre) && pre[i+1].IsNum {
+			n = pre[i+1].VersionNum
+		}
+		pre = append(pre[:i+1], PRVersion{VersionNum: n + 1, IsNum: true})
+	default:
+		n, err := strconv.ParseUint(strings.TrimPrefix(pre[i].VersionStr, id), 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse %s number: %w", id, err)
+		}
+		pre = append(pre[:i], PRVersion{VersionStr: id + strconv.FormatUint(n+1, 10)})
+	}
+
+	v.Pre = pre
+	v.Build = nil
+	return nil
+}
+
+// Finalize drops the prerelease and build metadata, turning 1.2.0-rc.2+b1
+// into 1.2.0.
+func (v *Version) Finalize() {
+	v.Pre = nil
+	v.Build = nil
+}
+
+// WithPrerelease returns a copy of v with the prerelease replaced by the
+// given identifiers. Identifiers may be dotted, WithPrerelease("rc.1") and
+// WithPrerelease("rc", "1") are the same. No identifiers drop the prerelease.
+func (v Version) WithPrerelease(identifiers ...string) (Version, error) {
+	var pre []PRVersion
+	for _, ids := range identifiers {
+		for _, s := range strings.Split(ids, ".") {
+			p, err := NewPRVersion(s)
+			if err != nil {
+				return Version{}, err
+			}
+			pre = append(pre, p)
+		}
+	}
+
+	v.Pre = pre
+	v.Build = append([]string(nil), v.Build...)
+	return v, nil
+}
+
+// prereleaseIndex returns the index of the prerelease identifier id in v or
+// -1 if there is none.
+func (v Version) prereleaseIndex(id string) int {
+	if id == "" {
+		return -1
+	}
+	for i, pre := range v.Pre {
+		if pre.IsNum || !strings.HasPrefix(pre.VersionStr, id) {
+			continue
+		}
+		if rest := strings.TrimPrefix(pre.VersionStr, id); rest == "" || containsOnly(rest, numbers) {
+			return i
+		}
+	}
+	return -1
+}
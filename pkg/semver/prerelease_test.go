@@ -0,0 +1,95 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion_IncrementPrerelease(t *testing.T) {
+	tests := []struct {
+		version string
+		id      string
+		want    string
+		wantErr string
+	}{
+		{version: "1.2.0-rc.1", id: "rc", want: "1.2.0-rc.2"},
+		{version: "1.2.0-rc1", id: "rc", want: "1.2.0-rc2"},
+		{version: "1.2.0-rc9", id: "rc", want: "1.2.0-rc10"},
+		{version: "1.2.0-rc", id: "rc", want: "1.2.0-rc.1"},
+		{version: "1.2.0-rc.1.dev.3+g1234567", id: "rc", want: "1.2.0-rc.2"},
+		{version: "1.2.0-beta.1", id: "rc", want: "1.2.0-rc.1"},
+		{version: "1.2.0-beta.rc.4", id: "rc", want: "1.2.0-beta.rc.5"},
+		{version: "1.2.0", id: "beta", want: "1.2.0-beta.1"},
+		{version: "v1.2.0-alpha.1", id: "alpha", want: "v1.2.0-alpha.2"},
+		{version: "1.2.0-rcx.1", id: "rc", want: "1.2.0-rc.1"},
+		{version: "1.2.0", id: "", wantErr: `invalid prerelease identifier ""`},
+		{version: "1.2.0", id: "rc1", wantErr: `invalid prerelease identifier "rc1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+"/"+tt.id, func(t *testing.T) {
+			v := MustParse(tt.version)
+			err := v.IncrementPrerelease(tt.id)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, v.String())
+		})
+	}
+}
+
+func TestVersion_IncrementPrereleaseDoesNotAlias(t *testing.T) {
+	v := MustParse("1.2.0-rc.1")
+	w := v
+	require.NoError(t, w.IncrementPrerelease("rc"))
+	require.Equal(t, "1.2.0-rc.1", v.String())
+	require.Equal(t, "1.2.0-rc.2", w.String())
+}
+
+func TestVersion_HasPrerelease(t *testing.T) {
+	require.True(t, MustParse("1.2.0-rc.1").HasPrerelease("rc"))
+	require.True(t, MustParse("1.2.0-rc1").HasPrerelease("rc"))
+	require.False(t, MustParse("1.2.0-rcx1").HasPrerelease("rc"))
+	require.False(t, MustParse("1.2.0").HasPrerelease("rc"))
+	require.False(t, MustParse("1.2.0-rc.1").HasPrerelease(""))
+}
+
+func TestVersion_Finalize(t *testing.T) {
+	v := MustParse("v1.2.0-rc.2+build.5")
+	v.Finalize()
+	require.Equal(t, "v1.2.0", v.String())
+}
+
+func TestVersion_WithPrerelease(t *testing.T) {
+	tests := []struct {
+		version     string
+		identifiers []string
+		want        string
+		wantErr     string
+	}{
+		{version: "1.2.0", identifiers: []string{"rc", "1"}, want: "1.2.0-rc.1"},
+		{version: "1.2.0", identifiers: []string{"rc.1"}, want: "1.2.0-rc.1"},
+		{version: "1.2.0", identifiers: []string{"rc1"}, want: "1.2.0-rc1"},
+		{version: "1.2.0-beta.2+b7", identifiers: []string{"rc", "1"}, want: "1.2.0-rc.1+b7"},
+		{version: "1.2.0-beta.2", identifiers: nil, want: "1.2.0"},
+		{version: "1.2.0", identifiers: []string{"rc", "01"}, wantErr: `numeric PreRelease version must not contain leading zeroes "01"`},
+		{version: "1.2.0", identifiers: []string{"rc..1"}, wantErr: "prerelease is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v := MustParse(tt.version)
+			got, err := v.WithPrerelease(tt.identifiers...)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.String())
+			require.Equal(t, tt.version, v.String(), "original modified")
+		})
+	}
+}
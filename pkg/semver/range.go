@@ -0,0 +1,279 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var rangePrefix = regexp.MustCompile(`^[a-zA-Z]+`)
+
+type operator int
+
+const (
+	opEQ operator = iota
+	opNE
+	opGT
+	opGTE
+	opLT
+	opLTE
+)
+
+type comparator struct {
+	op operator
+	v  Version
+}
+
+func (c comparator) match(v Version) bool {
+	cmp := v.Compare(c.v)
+	switch c.op {
+	case opNE:
+		return cmp != 0
+	case opGT:
+		return cmp > 0
+	case opGTE:
+		return cmp >= 0
+	case opLT:
+		return cmp < 0
+	case opLTE:
+		return cmp <= 0
+	default:
+		return cmp == 0
+	}
+}
+
+// Range is a set of versions described by a constraint expression.
+//
+// An expression is a list of alternatives separated by "||", each being a
+// list of comparators separated by whitespace that must all match.
+// Supported comparators are:
+//
+//	=1.2.3 1.2.3  exactly 1.2.3
+//	!=1.2.3       anything but 1.2.3
+//	>1.2.3 >=1.2.3 <1.2.3 <=1.2.3
+//	^1.2.3        >=1.2.3 <2.0.0, changes that do not modify the left-most non-zero part
+//	~1.2.3        >=1.2.3 <1.3.0, patch level changes
+//	1.2 1.2.x     >=1.2.0 <1.3.0
+//	1 1.x 1.x.x   >=1.0.0 <2.0.0
+//	* x           any version
+//
+// Upper bounds implied by ^, ~ and partial versions exclude prereleases of
+// the bound, so ^1.4 does not contain 2.0.0-rc1. Explicit comparators use
+// plain precedence, so <2.0.0 does contain 2.0.0-rc1. Prefixes such as "v"
+// are ignored.
+type Range struct {
+	expr string
+	sets [][]comparator
+}
+
+// ParseRange parses a range expression.
+func ParseRange(s string) (Range, error) {
+	r := Range{expr: strings.TrimSpace(s)}
+	if r.expr == "" {
+		return Range{}, errors.New("range is empty")
+	}
+
+	for _, alt := range strings.Split(r.expr, "||") {
+		fields := strings.Fields(strings.ReplaceAll(alt, ",", " "))
+		if len(fields) == 0 {
+			return Range{}, fmt.Errorf("empty alternative in range %q", s)
+		}
+
+		var set []comparator
+		for i := 0; i < len(fields); i++ {
+			f := fields[i]
+			// allow whitespace between operator and version
+			if strings.TrimLeft(f, "<>=!^~") == "" && i+1 < len(fields) {
+				i++
+				f += fields[i]
+			}
+			cs, err := parseComparator(f)
+			if err != nil {
+				return Range{}, fmt.Errorf("parse range %q: %w", s, err)
+			}
+			set = append(set, cs...)
+		}
+		r.sets = append(r.sets, set)
+	}
+
+	return r, nil
+}
+
+// MustParseRange is like ParseRange but panics if the range cannot be parsed.
+func MustParseRange(s string) Range {
+	r, err := ParseRange(s)
+	if err != nil {
+		panic(`semver: ParseRange(` + s + `): ` + err.Error())
+	}
+	return r
+}
+
+// Contains checks if v is in the range.
+func (r Range) Contains(v Version) bool {
+	for _, set := range r.sets {
+		ok := true
+		for _, c := range set {
+			if !c.match(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Range to string
+func (r Range) String() string {
+	return r.expr
+}
+
+// parseComparator parses a single comparator, expanding shorthands into
+// the comparators they stand for.
+func parseComparator(s string) ([]comparator, error) {
+	var op string
+	for _, o := range []string{">=", "<=", "!=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(s, o) {
+			op = o
+			break
+		}
+	}
+	s = strings.TrimPrefix(s, op)
+
+	v, n, err := parsePartial(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if n == 3 {
+		switch op {
+		case "", "=":
+			return []comparator{{opEQ, v}}, nil
+		case "!=":
+			return []comparator{{opNE, v}}, nil
+		case ">":
+			return []comparator{{opGT, v}}, nil
+		case ">=":
+			return []comparator{{opGTE, v}}, nil
+		case "<":
+			return []comparator{{opLT, v}}, nil
+		case "<=":
+			return []comparator{{opLTE, v}}, nil
+		case "^":
+			upper := Version{Major: v.Major + 1}
+			if v.Major == 0 && v.Minor > 0 {
+				upper = Version{Minor: v.Minor + 1}
+			} else if v.Major == 0 {
+				upper = Version{Patch: v.Patch + 1}
+			}
+			return []comparator{{opGTE, v}, {opLT, lowest(upper)}}, nil
+		default: // "~"
+			return []comparator{{opGTE, v}, {opLT, lowest(Version{Major: v.Major, Minor: v.Minor + 1})}}, nil
+		}
+	}
+
+	if n == 0 {
+		switch op {
+		case "", "=", ">=", "<=", "^", "~":
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("operator %q can not be used with wildcard %q", op, s)
+		}
+	}
+
+	// partial version, v is the lowest version it covers and next the
+	// lowest version above it
+	next := Version{Major: v.Major + 1}
+	if n == 2 {
+		next = Version{Major: v.Major, Minor: v.Minor + 1}
+		if op == "^" && v.Major > 0 {
+			next = Version{Major: v.Major + 1}
+		}
+	}
+
+	switch op {
+	case "", "=", "^", "~":
+		return []comparator{{opGTE, v}, {opLT, lowest(next)}}, nil
+	case ">":
+		return []comparator{{opGTE, next}}, nil
+	case ">=":
+		return []comparator{{opGTE, v}}, nil
+	case "<":
+		return []comparator{{opLT, lowest(v)}}, nil
+	case "<=":
+		return []comparator{{opLT, lowest(next)}}, nil
+	default:
+		return nil, fmt.Errorf("operator %q can not be used with partial version %q", op, s)
+	}
+}
+
+// parsePartial parses a possibly partial version like "1", "1.2", "1.x" or
+// "1.2.3-rc1" and returns it along with the number of specified parts.
+func parsePartial(s string) (Version, int, error) {
+	if s == "" {
+		return Version{}, 0, errors.New("missing version")
+	}
+	if s != "x" && s != "X" {
+		s = rangePrefix.ReplaceAllString(s, "")
+	}
+
+	main, rest := s, ""
+	if i := strings.IndexAny(s, "-+"); i != -1 {
+		main, rest = s[:i], s[i:]
+	}
+
+	parts := strings.Split(main, ".")
+	if len(parts) > 3 {
+		return Version{}, 0, fmt.Errorf("too many version parts in %q", s)
+	}
+
+	var nums []uint64
+	for _, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			break
+		}
+		if p == "" || !containsOnly(p, numbers) {
+			return Version{}, 0, fmt.Errorf("invalid version part %q in %q", p, s)
+		}
+		num, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return Version{}, 0, err
+		}
+		nums = append(nums, num)
+	}
+	for _, p := range parts[len(nums):] {
+		if p != "x" && p != "X" && p != "*" {
+			return Version{}, 0, fmt.Errorf("version part %q follows a wildcard in %q", p, s)
+		}
+	}
+
+	if len(nums) < 3 {
+		if rest != "" {
+			return Version{}, 0, fmt.Errorf("partial version %q can not have prerelease or build", s)
+		}
+		v := Version{}
+		if len(nums) > 0 {
+			v.Major = nums[0]
+		}
+		if len(nums) > 1 {
+			v.Minor = nums[1]
+		}
+		return v, len(nums), nil
+	}
+
+	v, err := Parse(s)
+	if err != nil {
+		return Version{}, 0, err
+	}
+	return v, 3, nil
+}
+
+// lowest returns the lowest possible prerelease of v.
+func lowest(v Version) Version {
+	v.Pre = []PRVersion{{VersionNum: 0, IsNum: true}}
+	return v
+}
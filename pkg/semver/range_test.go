@@ -0,0 +1,132 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRange_Contains(t *testing.T) {
+	tests := []struct {
+		name  string
+		expr  string
+		in    []string
+		notIn []string
+	}{
+		{
+			name:  "exact",
+			expr:  "1.2.3",
+			in:    []string{"1.2.3", "v1.2.3", "1.2.3+build"},
+			notIn: []string{"1.2.4", "1.2.3-rc1"},
+		},
+		{
+			name:  "not equal",
+			expr:  "!=1.2.3",
+			in:    []string{"1.2.4", "1.2.3-rc1"},
+			notIn: []string{"1.2.3"},
+		},
+		{
+			name:  "bounded",
+			expr:  ">=1.2.0 <2.0.0",
+			in:    []string{"1.2.0", "1.9.9", "2.0.0-rc1"},
+			notIn: []string{"1.1.9", "1.2.0-rc1", "2.0.0"},
+		},
+		{
+			name:  "operators separated from versions",
+			expr:  ">= 1.2.0, < 2.0.0",
+			in:    []string{"1.2.0", "1.9.9"},
+			notIn: []string{"2.0.0"},
+		},
+		{
+			name:  "caret partial",
+			expr:  "^1.4",
+			in:    []string{"1.4.0", "1.9.0"},
+			notIn: []string{"1.3.9", "2.0.0-rc1", "2.0.0"},
+		},
+		{
+			name:  "caret zero major",
+			expr:  "^0.2.3",
+			in:    []string{"0.2.3", "0.2.9"},
+			notIn: []string{"0.3.0", "0.2.2"},
+		},
+		{
+			name:  "caret zero minor",
+			expr:  "^0.0.3",
+			in:    []string{"0.0.3"},
+			notIn: []string{"0.0.4", "0.0.2"},
+		},
+		{
+			name:  "tilde",
+			expr:  "~1.4.2",
+			in:    []string{"1.4.2", "1.4.9"},
+			notIn: []string{"1.4.1", "1.5.0"},
+		},
+		{
+			name:  "x range",
+			expr:  "1.x",
+			in:    []string{"1.0.0", "1.99.0"},
+			notIn: []string{"0.9.0", "2.0.0-rc1", "2.0.0"},
+		},
+		{
+			name:  "minor x range",
+			expr:  "v1.2.x",
+			in:    []string{"1.2.0", "1.2.99"},
+			notIn: []string{"1.3.0", "1.1.0"},
+		},
+		{
+			name:  "partial comparators",
+			expr:  ">1.2 <=2",
+			in:    []string{"1.3.0", "2.9.9"},
+			notIn: []string{"1.2.9", "3.0.0"},
+		},
+		{
+			name: "wildcard",
+			expr: "*",
+			in:   []string{"0.0.0", "1.2.3-rc1", "9.9.9"},
+		},
+		{
+			name:  "alternatives",
+			expr:  "^1.2 || >=3.0.0 <3.1.0",
+			in:    []string{"1.2.0", "3.0.5"},
+			notIn: []string{"2.0.0", "3.1.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRange(tt.expr)
+			require.NoError(t, err)
+			require.Equal(t, tt.expr, r.String())
+			for _, v := range tt.in {
+				require.True(t, r.Contains(MustParse(v)), "expected %s in %s", v, tt.expr)
+			}
+			for _, v := range tt.notIn {
+				require.False(t, r.Contains(MustParse(v)), "expected %s not in %s", v, tt.expr)
+			}
+		})
+	}
+}
+
+func TestParseRange_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "empty", expr: ""},
+		{name: "empty alternative", expr: "1.2.3 ||"},
+		{name: "missing version", expr: ">="},
+		{name: "invalid part", expr: "1.a.3"},
+		{name: "too many parts", expr: "1.2.3.4"},
+		{name: "number after wildcard", expr: "1.x.3"},
+		{name: "partial with prerelease", expr: "1.2-rc1"},
+		{name: "not equal partial", expr: "!=1.2"},
+		{name: "less than wildcard", expr: "<*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRange(tt.expr)
+			require.Error(t, err)
+		})
+	}
+}
@@ -268,18 +268,25 @@ func Parse(s string) (Version, error) {
 		return Version{}, errors.New("version string empty")
 	}
 
+	// Path prefix, e.g. "api/" in "api/v1.2.3"
+	var prefix string
+	rest := s
+	if i := strings.LastIndex(s, "/"); i != -1 {
+		prefix, rest = s[:i+1], s[i+1:]
+	}
+
 	// Split into major.minor.(patch+pr+meta)
-	parts := strings.SplitN(s, ".", 3)
+	parts := strings.SplitN(rest, ".", 3)
 	if len(parts) != 3 {
 		return Version{}, errors.New("no Major.Minor.Patch elements found")
 	}
 
 	// Prefix
-	var prefix string
 	re := regexp.MustCompile(`^[a-zA-Z]+`)
 	if re.Match([]byte(parts[0])) {
-		prefix = re.FindString(parts[0])
-		parts[0] = strings.Replace(parts[0], prefix, "", 1)
+		alpha := re.FindString(parts[0])
+		prefix += alpha
+		parts[0] = strings.Replace(parts[0], alpha, "", 1)
 		if len(parts[0]) < 1 {
 			return Version{}, fmt.Errorf("missing major version number %q", s)
 		}
@@ -372,6 +379,22 @@ func MustParse(s string) Version {
 	return v
 }
 
+// SplitPrefix splits s into the prefix, a path up to the last "/" followed
+// by letters like in "api/v1.2.3", and the rest.
+func SplitPrefix(s string) (prefix, rest string) {
+	rest = s
+	if i := strings.LastIndex(s, "/"); i != -1 {
+		prefix, rest = s[:i+1], s[i+1:]
+	}
+	i := strings.IndexFunc(rest, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+	if i == -1 {
+		i = len(rest)
+	}
+	return prefix + rest[:i], rest[i:]
+}
+
 // PRVersion represents a PreRelease Version
 type PRVersion struct {
 	VersionStr string
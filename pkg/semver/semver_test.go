@@ -257,6 +257,26 @@ func TestParse(t *testing.T) {
 				Prefix: "v",
 			},
 		},
+		{
+			name:    "api/v1.2.3",
+			version: "api/v1.2.3",
+			want: Version{
+				Major:  1,
+				Minor:  2,
+				Patch:  3,
+				Prefix: "api/v",
+			},
+		},
+		{
+			name:    "services/worker/0.4.0",
+			version: "services/worker/0.4.0",
+			want: Version{
+				Major:  0,
+				Minor:  4,
+				Patch:  0,
+				Prefix: "services/worker/",
+			},
+		},
 		{
 			name:    "0.0.1-alphanumeric1",
 			version: "0.0.1-alphanumeric1",
@@ -385,6 +405,16 @@ func TestParse(t *testing.T) {
 			version: "major.0.1",
 			wantErr: errors.New(`missing major version number "major.0.1"`),
 		},
+		{
+			name:    "api/",
+			version: "api/",
+			wantErr: errors.New("no Major.Minor.Patch elements found"),
+		},
+		{
+			name:    "api/v.0.1",
+			version: "api/v.0.1",
+			wantErr: errors.New(`missing major version number "api/v.0.1"`),
+		},
 		{
 			name:    "0NaN.0.1",
 			version: "0NaN.0.1",
@@ -1066,3 +1096,20 @@ func TestNewBuildVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitPrefix(t *testing.T) {
+	tests := []struct {
+		in, prefix, rest string
+	}{
+		{"1.2.3", "", "1.2.3"},
+		{"v1.2.3", "v", "1.2.3"},
+		{"api/v1.2.3", "api/v", "1.2.3"},
+		{"svc/api/26.10.0", "svc/api/", "26.10.0"},
+		{"release", "release", ""},
+	}
+	for _, tt := range tests {
+		prefix, rest := SplitPrefix(tt.in)
+		require.Equal(t, tt.prefix, prefix, tt.in)
+		require.Equal(t, tt.rest, rest, tt.in)
+	}
+}
@@ -0,0 +1,122 @@
+package semver
+
+import (
+	"sort"
+)
+
+// Versions is a collection of versions implementing sort.Interface, ordered
+// by precedence.
+type Versions []Version
+
+// Grouping selects the release lines versions are grouped into.
+type Grouping int
+
+const (
+	// ByMajor groups versions by major version, 1.x, 2.x, ...
+	ByMajor Grouping = iota
+
+	// ByMinor groups versions by major and minor version, 1.2.x, 1.3.x, ...
+	ByMinor
+)
+
+func (vs Versions) Len() int {
+	return len(vs)
+}
+
+func (vs Versions) Less(i, j int) bool {
+	return vs[i].LT(vs[j])
+}
+
+func (vs Versions) Swap(i, j int) {
+	vs[i], vs[j] = vs[j], vs[i]
+}
+
+// Sort sorts vs in ascending order, keeping the order of equal versions.
+func (vs Versions) Sort() {
+	sort.Stable(vs)
+}
+
+// Filter returns the versions for which keep returns true.
+func (vs Versions) Filter(keep func(Version) bool) Versions {
+	var out Versions
+	for _, v := range vs {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Stable returns the versions without a prerelease.
+func (vs Versions) Stable() Versions {
+	return vs.Filter(func(v Version) bool { return len(v.Pre) == 0 })
+}
+
+// Prereleases returns the versions with a prerelease.
+func (vs Versions) Prereleases() Versions {
+	return vs.Filter(func(v Version) bool { return len(v.Pre) > 0 })
+}
+
+// Max returns the highest version. The second return value is false if vs
+// is empty. Of equal versions the first is returned.
+func (vs Versions) Max() (Version, bool) {
+	if len(vs) == 0 {
+		return Version{}, false
+	}
+	highest := vs[0]
+	for _, v := range vs[1:] {
+		if v.GT(highest) {
+			highest = v
+		}
+	}
+	return highest, true
+}
+
+// Dedupe returns the versions sorted in ascending order with versions that
+// only differ in build metadata or prefix removed, keeping the first.
+func (vs Versions) Dedupe() Versions {
+	sorted := append(Versions(nil), vs...)
+	sorted.Sort()
+
+	var out Versions
+	for _, v := range sorted {
+		if len(out) > 0 && out[len(out)-1].EQ(v) {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// Group returns the versions grouped into release lines, ordered by line
+// with the versions of each line sorted in ascending order.
+func (vs Versions) Group(by Grouping) []Versions {
+	sorted := append(Versions(nil), vs...)
+	sorted.Sort()
+
+	var out []Versions
+	for i, v := range sorted {
+		if i == 0 || !sameLine(sorted[i-1], v, by) {
+			out = append(out, nil)
+		}
+		out[len(out)-1] = append(out[len(out)-1], v)
+	}
+	return out
+}
+
+// Latest returns the highest version of each release line in ascending
+// order, e.g. the latest patch of each minor version with ByMinor.
+func (vs Versions) Latest(by Grouping) Versions {
+	var out Versions
+	for _, line := range vs.Group(by) {
+		out = append(out, line[len(line)-1])
+	}
+	return out
+}
+
+func sameLine(a, b Version, by Grouping) bool {
+	if by == ByMinor {
+		return a.Major == b.Major && a.Minor == b.Minor
+	}
+	return a.Major == b.Major
+}
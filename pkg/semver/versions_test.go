@@ -0,0 +1,99 @@
+package semver
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func versions(t *testing.T, ss ...string) Versions {
+	t.Helper()
+	out := make(Versions, 0, len(ss))
+	for _, s := range ss {
+		v, err := Parse(s)
+		require.NoError(t, err)
+		out = append(out, v)
+	}
+	return out
+}
+
+func strs(vs Versions) []string {
+	out := make([]string, 0, len(vs))
+	for _, v := range vs {
+		out = append(out, v.String())
+	}
+	return out
+}
+
+func TestVersions_Sort(t *testing.T) {
+	vs := versions(t, "1.10.0", "1.2.0", "1.2.0-rc.1", "0.9.9", "1.2.0-rc9", "2.0.0", "1.2.0-rc.10", "1.2.0-rc.2", "1.2.0-rc10")
+	vs.Sort()
+	require.Equal(t, []string{"0.9.9", "1.2.0-rc.1", "1.2.0-rc.2", "1.2.0-rc.10", "1.2.0-rc9", "1.2.0-rc10", "1.2.0", "1.10.0", "2.0.0"}, strs(vs))
+
+	vs = versions(t, "3.0.0", "1.0.0")
+	sort.Sort(sort.Reverse(vs))
+	require.Equal(t, []string{"3.0.0", "1.0.0"}, strs(vs))
+}
+
+func TestVersions_Filter(t *testing.T) {
+	vs := versions(t, "1.0.0", "1.1.0-rc.1", "1.1.0", "2.0.0-beta.1", "2.1.0")
+
+	require.Equal(t, []string{"1.0.0", "1.1.0", "2.1.0"}, strs(vs.Stable()))
+	require.Equal(t, []string{"1.1.0-rc.1", "2.0.0-beta.1"}, strs(vs.Prereleases()))
+
+	r := MustParseRange(">=2.0.0 <3.0.0")
+	require.Equal(t, []string{"2.1.0"}, strs(vs.Filter(r.Contains)))
+	require.Empty(t, Versions(nil).Stable())
+}
+
+func TestVersions_Max(t *testing.T) {
+	v, ok := versions(t, "1.0.0", "v1.2.0-rc.2", "v1.1.9", "1.2.0-rc.1").Max()
+	require.True(t, ok)
+	require.Equal(t, "v1.2.0-rc.2", v.String())
+
+	v, ok = versions(t, "1.0.0+a", "1.0.0+b").Max()
+	require.True(t, ok)
+	require.Equal(t, "1.0.0+a", v.String())
+
+	_, ok = Versions(nil).Max()
+	require.False(t, ok)
+}
+
+func TestVersions_Dedupe(t *testing.T) {
+	vs := versions(t, "1.1.0", "1.0.0+build.2", "1.0.0+build.1", "1.1.0-rc.1", "v1.1.0", "1.0.0")
+	require.Equal(t, []string{"1.0.0+build.2", "1.1.0-rc.1", "1.1.0"}, strs(vs.Dedupe()))
+	require.Equal(t, "1.1.0", vs[0].String(), "input modified")
+}
+
+func TestVersions_Group(t *testing.T) {
+	vs := versions(t, "2.0.1", "1.2.3", "1.3.0", "1.2.10", "2.0.0", "1.3.1-rc.1", "0.1.0")
+
+	require.Equal(t, [][]string{
+		{"0.1.0"},
+		{"1.2.3", "1.2.10", "1.3.0", "1.3.1-rc.1"},
+		{"2.0.0", "2.0.1"},
+	}, groupStrs(vs.Group(ByMajor)))
+	require.Equal(t, [][]string{
+		{"0.1.0"},
+		{"1.2.3", "1.2.10"},
+		{"1.3.0", "1.3.1-rc.1"},
+		{"2.0.0", "2.0.1"},
+	}, groupStrs(vs.Group(ByMinor)))
+	require.Empty(t, Versions(nil).Group(ByMinor))
+}
+
+func TestVersions_Latest(t *testing.T) {
+	vs := versions(t, "2.0.1", "1.2.3", "1.3.0", "1.2.10", "2.0.0", "1.3.1-rc.1", "0.1.0")
+
+	require.Equal(t, []string{"0.1.0", "1.3.1-rc.1", "2.0.1"}, strs(vs.Latest(ByMajor)))
+	require.Equal(t, []string{"0.1.0", "1.2.10", "1.3.0", "2.0.1"}, strs(vs.Stable().Latest(ByMinor)))
+}
+
+func groupStrs(groups []Versions) [][]string {
+	out := make([][]string, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, strs(g))
+	}
+	return out
+}